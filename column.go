@@ -0,0 +1,187 @@
+package mysql
+
+import (
+	"github.com/goravel/framework/contracts/database/driver"
+
+	"github.com/goravel/mysql/contracts"
+)
+
+var _ contracts.ColumnDefinition = &ColumnDefinition{}
+
+// columns The MySQL attributes of the columns that are created by the blueprint, the blueprint only keeps the column
+// it creates, so the attributes are found by the column when it's compiled.
+var columns = newRegistry[columnAttributes]()
+
+type ColumnDefinition struct {
+	driver.ColumnDefinition
+	attributes *columnAttributes
+}
+
+type columnAttributes struct {
+	algorithm string
+	allowed   []any
+	charset   string
+	collation string
+	length    int
+	lock      string
+	srid      int
+	storedAs  string
+	virtualAs string
+}
+
+// Column returns the MySQL attributes of a column of the blueprint:
+//
+//	mysql.Column(table.String("token", 64)).Collation("utf8mb4_bin")
+func Column(column driver.ColumnDefinition) *ColumnDefinition {
+	if definition, ok := column.(*ColumnDefinition); ok {
+		return definition
+	}
+
+	return &ColumnDefinition{ColumnDefinition: column, attributes: columns.getOrCreate(column)}
+}
+
+// Algorithm sets the ALGORITHM of the alter table statement that adds or changes the column, see TableDefinition.Algorithm.
+func (r *ColumnDefinition) Algorithm(algorithm string) *ColumnDefinition {
+	r.attributes.algorithm = algorithm
+
+	return r
+}
+
+// Allowed sets the allowed values of the set column:
+//
+//	mysql.Column(table.Column("permissions", "set")).Allowed("read", "write")
+func (r *ColumnDefinition) Allowed(values ...any) *ColumnDefinition {
+	r.attributes.allowed = values
+
+	return r
+}
+
+// Charset sets the character set of the column.
+func (r *ColumnDefinition) Charset(charset string) *ColumnDefinition {
+	r.attributes.charset = charset
+
+	return r
+}
+
+// Collation sets the collation of the column.
+func (r *ColumnDefinition) Collation(collation string) *ColumnDefinition {
+	r.attributes.collation = collation
+
+	return r
+}
+
+// Length sets the length of the binary, varBinary and bit columns:
+//
+//	mysql.Column(table.Column("token", "binary")).Length(16)
+func (r *ColumnDefinition) Length(length int) *ColumnDefinition {
+	r.attributes.length = length
+
+	return r
+}
+
+// Lock sets the LOCK of the alter table statement that adds or changes the column, see TableDefinition.Lock.
+func (r *ColumnDefinition) Lock(lock string) *ColumnDefinition {
+	r.attributes.lock = lock
+
+	return r
+}
+
+// Srid sets the spatial reference system of the spatial column, e.g. 4326, a SPATIAL index requires it.
+func (r *ColumnDefinition) Srid(srid int) *ColumnDefinition {
+	r.attributes.srid = srid
+
+	return r
+}
+
+// StoredAs declares a stored generated column, the expression is raw SQL:
+//
+//	mysql.Column(table.Integer("total")).StoredAs("`price` * `quantity`")
+func (r *ColumnDefinition) StoredAs(expression string) *ColumnDefinition {
+	r.attributes.storedAs = expression
+
+	return r
+}
+
+// VirtualAs declares a virtual generated column, the expression is raw SQL:
+//
+//	mysql.Column(table.Integer("total")).VirtualAs("`price` * `quantity`")
+func (r *ColumnDefinition) VirtualAs(expression string) *ColumnDefinition {
+	r.attributes.virtualAs = expression
+
+	return r
+}
+
+func (r *ColumnDefinition) GetAlgorithm() string {
+	return r.attributes.algorithm
+}
+
+func (r *ColumnDefinition) GetAllowed() []any {
+	if r.attributes.allowed != nil {
+		return r.attributes.allowed
+	}
+
+	return r.ColumnDefinition.GetAllowed()
+}
+
+func (r *ColumnDefinition) GetCharset() string {
+	return r.attributes.charset
+}
+
+func (r *ColumnDefinition) GetCollation() string {
+	return r.attributes.collation
+}
+
+func (r *ColumnDefinition) GetLength() int {
+	if r.attributes.length > 0 {
+		return r.attributes.length
+	}
+
+	return r.ColumnDefinition.GetLength()
+}
+
+func (r *ColumnDefinition) GetLock() string {
+	return r.attributes.lock
+}
+
+func (r *ColumnDefinition) GetSrid() int {
+	return r.attributes.srid
+}
+
+func (r *ColumnDefinition) GetStoredAs() string {
+	return r.attributes.storedAs
+}
+
+func (r *ColumnDefinition) GetVirtualAs() string {
+	return r.attributes.virtualAs
+}
+
+// columnAllowed returns the allowed values of the column, the ones that are set via ColumnDefinition.Allowed first.
+func columnAllowed(column driver.ColumnDefinition) []any {
+	if definition := columnDefinition(column); definition != nil {
+		return definition.GetAllowed()
+	}
+
+	return column.GetAllowed()
+}
+
+// columnLength returns the length of the column, the one that is set via ColumnDefinition.Length first.
+func columnLength(column driver.ColumnDefinition) int {
+	if definition := columnDefinition(column); definition != nil {
+		return definition.GetLength()
+	}
+
+	return column.GetLength()
+}
+
+// columnDefinition returns the MySQL attributes of the column, nil if they aren't set.
+func columnDefinition(column driver.ColumnDefinition) contracts.ColumnDefinition {
+	if definition, ok := column.(contracts.ColumnDefinition); ok {
+		return definition
+	}
+
+	if attributes := columns.get(column); attributes != nil {
+		return &ColumnDefinition{ColumnDefinition: column, attributes: attributes}
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/goravel/framework/database/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColumn(t *testing.T) {
+	column := schema.NewColumnDefinition("token", "string")
+	assert.Nil(t, columnDefinition(column))
+
+	definition := Column(column).Charset("utf8mb4").Collation("utf8mb4_bin")
+	assert.Equal(t, definition, Column(column))
+	assert.Same(t, definition, Column(definition))
+	assert.Equal(t, "token", definition.GetName())
+
+	attributes := columnDefinition(column)
+	assert.Equal(t, "utf8mb4", attributes.GetCharset())
+	assert.Equal(t, "utf8mb4_bin", attributes.GetCollation())
+	assert.Equal(t, attributes, columnDefinition(definition))
+	assert.Equal(t, 0, attributes.GetLength())
+	assert.Nil(t, attributes.GetAllowed())
+
+	definition.Length(16).Allowed("read", "write")
+	assert.Equal(t, 16, columnLength(column))
+	assert.Equal(t, []any{"read", "write"}, columnAllowed(column))
+}
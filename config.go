@@ -2,8 +2,10 @@ package mysql
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/goravel/framework/contracts/config"
+	"github.com/spf13/cast"
 
 	"github.com/goravel/mysql/contracts"
 )
@@ -55,12 +57,15 @@ func (r *Config) fillDefault(configs []contracts.Config) []contracts.FullConfig
 	var fullConfigs []contracts.FullConfig
 	for _, config := range configs {
 		fullConfig := contracts.FullConfig{
-			Config:      config,
-			Connection:  r.connection,
-			Driver:      Name,
-			NoLowerCase: r.config.GetBool(fmt.Sprintf("database.connections.%s.no_lower_case", r.connection)),
-			Prefix:      r.config.GetString(fmt.Sprintf("database.connections.%s.prefix", r.connection)),
-			Singular:    r.config.GetBool(fmt.Sprintf("database.connections.%s.singular", r.connection)),
+			Config:         config,
+			CoalesceAlters: r.config.GetBool(fmt.Sprintf("database.connections.%s.coalesce_alters", r.connection)),
+			Collation:      r.config.GetString(fmt.Sprintf("database.connections.%s.collation", r.connection)),
+			Connection:     r.connection,
+			Driver:         Name,
+			Engine:         r.config.GetString(fmt.Sprintf("database.connections.%s.engine", r.connection)),
+			NoLowerCase:    r.config.GetBool(fmt.Sprintf("database.connections.%s.no_lower_case", r.connection)),
+			Prefix:         r.config.GetString(fmt.Sprintf("database.connections.%s.prefix", r.connection)),
+			Singular:       r.config.GetBool(fmt.Sprintf("database.connections.%s.singular", r.connection)),
 		}
 		if nameReplacer := r.config.Get(fmt.Sprintf("database.connections.%s.name_replacer", r.connection)); nameReplacer != nil {
 			if replacer, ok := nameReplacer.(contracts.Replacer); ok {
@@ -68,6 +73,35 @@ func (r *Config) fillDefault(configs []contracts.Config) []contracts.FullConfig
 			}
 		}
 
+		fullConfig.Session = r.session()
+
+		fullConfig.ReplicaCheck = contracts.ReplicaCheck{
+			Interval: r.config.GetDuration(fmt.Sprintf("database.connections.%s.replica_check.interval", r.connection)) * time.Second,
+			MaxLag:   r.config.GetDuration(fmt.Sprintf("database.connections.%s.replica_check.max_lag", r.connection)) * time.Second,
+		}
+
+		fullConfig.ReadYourWrites = contracts.ReadYourWrites{
+			Mode:    r.config.GetString(fmt.Sprintf("database.connections.%s.read_your_writes.mode", r.connection)),
+			Timeout: r.config.GetDuration(fmt.Sprintf("database.connections.%s.read_your_writes.timeout", r.connection)) * time.Second,
+		}
+
+		fullConfig.OnlineDDL = contracts.OnlineDDL{
+			Algorithm: r.config.GetString(fmt.Sprintf("database.connections.%s.online_ddl.algorithm", r.connection)),
+			Lock:      r.config.GetString(fmt.Sprintf("database.connections.%s.online_ddl.lock", r.connection)),
+			Strict:    r.config.GetBool(fmt.Sprintf("database.connections.%s.online_ddl.strict", r.connection)),
+		}
+
+		fullConfig.Uuid = contracts.Uuid{
+			Binary:   r.config.GetBool(fmt.Sprintf("database.connections.%s.uuid.binary", r.connection)),
+			SwapFlag: r.config.GetBool(fmt.Sprintf("database.connections.%s.uuid.swap_flag", r.connection)),
+		}
+
+		fullConfig.Retry = contracts.Retry{
+			MaxAttempts: r.config.GetInt(fmt.Sprintf("database.connections.%s.retry.max_attempts", r.connection)),
+			Backoff:     r.config.GetDuration(fmt.Sprintf("database.connections.%s.retry.backoff", r.connection)) * time.Millisecond,
+			Codes:       r.retryCodes(),
+		}
+
 		// If read or write is empty, use the default config
 		if fullConfig.Dsn == "" {
 			fullConfig.Dsn = r.config.GetString(fmt.Sprintf("database.connections.%s.dsn", r.connection))
@@ -75,6 +109,9 @@ func (r *Config) fillDefault(configs []contracts.Config) []contracts.FullConfig
 		if fullConfig.Host == "" {
 			fullConfig.Host = r.config.GetString(fmt.Sprintf("database.connections.%s.host", r.connection))
 		}
+		if fullConfig.Socket == "" {
+			fullConfig.Socket = r.config.GetString(fmt.Sprintf("database.connections.%s.socket", r.connection))
+		}
 		if fullConfig.Port == 0 {
 			fullConfig.Port = r.config.GetInt(fmt.Sprintf("database.connections.%s.port", r.connection))
 		}
@@ -90,6 +127,34 @@ func (r *Config) fillDefault(configs []contracts.Config) []contracts.FullConfig
 		if fullConfig.Charset == "" {
 			fullConfig.Charset = r.config.GetString(fmt.Sprintf("database.connections.%s.charset", r.connection))
 		}
+		if fullConfig.Pool.MaxOpenConns == 0 {
+			fullConfig.Pool.MaxOpenConns = r.config.GetInt(fmt.Sprintf("database.connections.%s.pool.max_open_conns", r.connection))
+		}
+		if fullConfig.Pool.MaxIdleConns == 0 {
+			fullConfig.Pool.MaxIdleConns = r.config.GetInt(fmt.Sprintf("database.connections.%s.pool.max_idle_conns", r.connection))
+		}
+		if fullConfig.Pool.ConnMaxLifetime == 0 {
+			fullConfig.Pool.ConnMaxLifetime = r.config.GetDuration(fmt.Sprintf("database.connections.%s.pool.conn_max_lifetime", r.connection)) * time.Second
+		}
+		if fullConfig.Pool.ConnMaxIdleTime == 0 {
+			fullConfig.Pool.ConnMaxIdleTime = r.config.GetDuration(fmt.Sprintf("database.connections.%s.pool.conn_max_idle_time", r.connection)) * time.Second
+		}
+		fullConfig.Params = r.params(config.Params)
+		if fullConfig.Sslmode == "" {
+			fullConfig.Sslmode = r.config.GetString(fmt.Sprintf("database.connections.%s.sslmode", r.connection))
+		}
+		if fullConfig.SslCa == "" {
+			fullConfig.SslCa = r.config.GetString(fmt.Sprintf("database.connections.%s.ssl_ca", r.connection))
+		}
+		if fullConfig.SslCert == "" {
+			fullConfig.SslCert = r.config.GetString(fmt.Sprintf("database.connections.%s.ssl_cert", r.connection))
+		}
+		if fullConfig.SslKey == "" {
+			fullConfig.SslKey = r.config.GetString(fmt.Sprintf("database.connections.%s.ssl_key", r.connection))
+		}
+		if fullConfig.SslServerName == "" {
+			fullConfig.SslServerName = r.config.GetString(fmt.Sprintf("database.connections.%s.ssl_server_name", r.connection))
+		}
 		if fullConfig.Loc == "" {
 			loc := r.config.GetString(fmt.Sprintf("database.connections.%s.loc", r.connection))
 			if loc == "" {
@@ -102,3 +167,54 @@ func (r *Config) fillDefault(configs []contracts.Config) []contracts.FullConfig
 
 	return fullConfigs
 }
+
+// params merges the connection level DSN parameters with the node level ones, the node level ones win.
+func (r *Config) params(nodeParams map[string]string) map[string]string {
+	connectionParams := cast.ToStringMapString(r.config.Get(fmt.Sprintf("database.connections.%s.params", r.connection)))
+	if len(connectionParams) == 0 && len(nodeParams) == 0 {
+		return nil
+	}
+
+	params := make(map[string]string, len(connectionParams)+len(nodeParams))
+	for key, value := range connectionParams {
+		params[key] = value
+	}
+	for key, value := range nodeParams {
+		params[key] = value
+	}
+
+	return params
+}
+
+// session returns the session config of the connection, it's either a contracts.Session or a map of the keys below.
+func (r *Config) session() contracts.Session {
+	switch session := r.config.Get(fmt.Sprintf("database.connections.%s.session", r.connection)).(type) {
+	case nil:
+		return contracts.Session{}
+	case contracts.Session:
+		return session
+	}
+
+	return contracts.Session{
+		SqlMode:              r.config.GetString(fmt.Sprintf("database.connections.%s.session.sql_mode", r.connection)),
+		TimeZone:             r.config.GetString(fmt.Sprintf("database.connections.%s.session.time_zone", r.connection)),
+		TransactionIsolation: r.config.GetString(fmt.Sprintf("database.connections.%s.session.transaction_isolation", r.connection)),
+		Collation:            r.config.GetString(fmt.Sprintf("database.connections.%s.session.collation", r.connection)),
+		Statements:           cast.ToStringSlice(r.config.Get(fmt.Sprintf("database.connections.%s.session.statements", r.connection))),
+	}
+}
+
+// retryCodes returns the retryable server error codes of the connection.
+func (r *Config) retryCodes() []uint16 {
+	codes := cast.ToIntSlice(r.config.Get(fmt.Sprintf("database.connections.%s.retry.codes", r.connection)))
+	if len(codes) == 0 {
+		return nil
+	}
+
+	retryCodes := make([]uint16, len(codes))
+	for i, code := range codes {
+		retryCodes[i] = uint16(code)
+	}
+
+	return retryCodes
+}
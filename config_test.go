@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
@@ -29,28 +30,97 @@ func (s *ConfigTestSuite) SetupTest() {
 	s.config = NewConfig(s.mockConfig, s.connection)
 }
 
+// expectFillDefault expects the connection configs that fillDefault reads for the node, values overrides the returned
+// value of a key. The fallback keys are only expected when the node doesn't set them.
+func (s *ConfigTestSuite) expectFillDefault(node contracts.Config, values map[string]any) {
+	expects := map[string]any{
+		"coalesce_alters":          false,
+		"collation":                "",
+		"engine":                   "",
+		"no_lower_case":            false,
+		"prefix":                   "goravel_",
+		"singular":                 false,
+		"name_replacer":            nil,
+		"session":                  nil,
+		"replica_check.interval":   time.Duration(0),
+		"replica_check.max_lag":    time.Duration(0),
+		"read_your_writes.mode":    "",
+		"read_your_writes.timeout": time.Duration(0),
+		"online_ddl.algorithm":     "",
+		"online_ddl.lock":          "",
+		"online_ddl.strict":        false,
+		"uuid.binary":              false,
+		"uuid.swap_flag":           false,
+		"retry.max_attempts":       0,
+		"retry.backoff":            time.Duration(0),
+		"retry.codes":              nil,
+		"params":                   nil,
+		"charset":                  "utf8mb4",
+		"loc":                      "UTC",
+	}
+	for key, fallback := range map[string]struct {
+		set   bool
+		value any
+	}{
+		"dsn":                     {node.Dsn != "", "dsn"},
+		"host":                    {node.Host != "", "localhost"},
+		"socket":                  {node.Socket != "", ""},
+		"port":                    {node.Port != 0, 3306},
+		"username":                {node.Username != "", "root"},
+		"password":                {node.Password != "", "123123"},
+		"database":                {node.Database != "", "forge"},
+		"pool.max_open_conns":     {node.Pool.MaxOpenConns != 0, 0},
+		"pool.max_idle_conns":     {node.Pool.MaxIdleConns != 0, 0},
+		"pool.conn_max_lifetime":  {node.Pool.ConnMaxLifetime != 0, time.Duration(0)},
+		"pool.conn_max_idle_time": {node.Pool.ConnMaxIdleTime != 0, time.Duration(0)},
+		"sslmode":                 {node.Sslmode != "", ""},
+		"ssl_ca":                  {node.SslCa != "", ""},
+		"ssl_cert":                {node.SslCert != "", ""},
+		"ssl_key":                 {node.SslKey != "", ""},
+		"ssl_server_name":         {node.SslServerName != "", ""},
+	} {
+		if !fallback.set {
+			expects[key] = fallback.value
+		}
+	}
+
+	for key, value := range expects {
+		if override, ok := values[key]; ok {
+			value = override
+		}
+
+		path := fmt.Sprintf("database.connections.%s.%s", s.connection, key)
+		switch value := value.(type) {
+		case string:
+			s.mockConfig.EXPECT().GetString(path).Return(value).Once()
+		case bool:
+			s.mockConfig.EXPECT().GetBool(path).Return(value).Once()
+		case int:
+			s.mockConfig.EXPECT().GetInt(path).Return(value).Once()
+		case time.Duration:
+			s.mockConfig.EXPECT().GetDuration(path).Return(value).Once()
+		default:
+			s.mockConfig.EXPECT().Get(path).Return(value).Once()
+		}
+	}
+}
+
 func (s *ConfigTestSuite) TestReads() {
 	// Test when configs is empty
 	s.mockConfig.EXPECT().Get(fmt.Sprintf("database.connections.%s.read", s.connection)).Return(nil).Once()
 	s.Nil(s.config.Readers())
 
 	// Test when configs is not empty
-	s.mockConfig.EXPECT().Get("database.connections.mysql.read").Return([]contracts.Config{
-		{
-			Dsn:      "dsn",
-			Database: "forge",
-			Host:     "localhost",
-			Port:     3306,
-			Username: "root",
-			Password: "123123",
-		},
-	}).Once()
-	s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.prefix", s.connection)).Return("goravel_").Once()
-	s.mockConfig.EXPECT().GetBool(fmt.Sprintf("database.connections.%s.singular", s.connection)).Return(false).Once()
-	s.mockConfig.EXPECT().GetBool(fmt.Sprintf("database.connections.%s.no_lower_case", s.connection)).Return(false).Once()
-	s.mockConfig.EXPECT().Get(fmt.Sprintf("database.connections.%s.name_replacer", s.connection)).Return(nil).Once()
-	s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.charset", s.connection)).Return("utf8mb4").Once()
-	s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.loc", s.connection)).Return("UTC").Once()
+	reader := contracts.Config{
+		Dsn:      "dsn",
+		Database: "forge",
+		Host:     "localhost",
+		Port:     3306,
+		Username: "root",
+		Password: "123123",
+	}
+	s.mockConfig.EXPECT().Get("database.connections.mysql.read").Return([]contracts.Config{reader}).Once()
+	s.expectFillDefault(reader, nil)
 	s.Equal([]contracts.FullConfig{
 		{
 			Connection:   s.connection,
@@ -76,18 +146,7 @@ func (s *ConfigTestSuite) TestReads() {
 func (s *ConfigTestSuite) TestWrites() {
 	s.Run("success when configs is empty", func() {
 		s.mockConfig.EXPECT().Get(fmt.Sprintf("database.connections.%s.write", s.connection)).Return(nil).Once()
-		s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.prefix", s.connection)).Return("goravel_").Once()
-		s.mockConfig.EXPECT().GetBool(fmt.Sprintf("database.connections.%s.singular", s.connection)).Return(false).Once()
-		s.mockConfig.EXPECT().GetBool(fmt.Sprintf("database.connections.%s.no_lower_case", s.connection)).Return(false).Once()
-		s.mockConfig.EXPECT().Get(fmt.Sprintf("database.connections.%s.name_replacer", s.connection)).Return(nil).Once()
-		s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.charset", s.connection)).Return("utf8mb4").Once()
-		s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.loc", s.connection)).Return("UTC").Once()
-		s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.dsn", s.connection)).Return("dsn").Once()
-		s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.host", s.connection)).Return("localhost").Once()
-		s.mockConfig.EXPECT().GetInt(fmt.Sprintf("database.connections.%s.port", s.connection)).Return(3306).Once()
-		s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.username", s.connection)).Return("root").Once()
-		s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.password", s.connection)).Return("123123").Once()
-		s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.database", s.connection)).Return("forge").Once()
+		s.expectFillDefault(contracts.Config{}, nil)
 
 		s.Equal([]contracts.FullConfig{
 			{
@@ -112,22 +171,9 @@ func (s *ConfigTestSuite) TestWrites() {
 	})
 
 	s.Run("success when configs is not empty", func() {
-		s.mockConfig.EXPECT().Get(fmt.Sprintf("database.connections.%s.write", s.connection)).Return([]contracts.Config{
-			{
-				Database: "forge",
-			},
-		}).Once()
-		s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.prefix", s.connection)).Return("goravel_").Once()
-		s.mockConfig.EXPECT().GetBool(fmt.Sprintf("database.connections.%s.singular", s.connection)).Return(false).Once()
-		s.mockConfig.EXPECT().GetBool(fmt.Sprintf("database.connections.%s.no_lower_case", s.connection)).Return(false).Once()
-		s.mockConfig.EXPECT().Get(fmt.Sprintf("database.connections.%s.name_replacer", s.connection)).Return(nil).Once()
-		s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.charset", s.connection)).Return("utf8mb4").Once()
-		s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.loc", s.connection)).Return("UTC").Once()
-		s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.dsn", s.connection)).Return("dsn").Once()
-		s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.host", s.connection)).Return("localhost").Once()
-		s.mockConfig.EXPECT().GetInt(fmt.Sprintf("database.connections.%s.port", s.connection)).Return(3306).Once()
-		s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.username", s.connection)).Return("root").Once()
-		s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.password", s.connection)).Return("123123").Once()
+		writer := contracts.Config{Database: "forge"}
+		s.mockConfig.EXPECT().Get(fmt.Sprintf("database.connections.%s.write", s.connection)).Return([]contracts.Config{writer}).Once()
+		s.expectFillDefault(writer, nil)
 
 		s.Equal([]contracts.FullConfig{
 			{
@@ -168,31 +214,18 @@ func (s *ConfigTestSuite) TestFillDefault() {
 	tests := []struct {
 		name          string
 		configs       []contracts.Config
+		values        map[string]any
 		setup         func()
 		expectConfigs []contracts.FullConfig
 	}{
 		{
 			name:    "success when configs is empty",
-			setup:   func() {},
 			configs: []contracts.Config{},
 		},
 		{
 			name:    "success when configs have item but key is empty",
 			configs: []contracts.Config{{}},
-			setup: func() {
-				s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.prefix", s.connection)).Return(prefix).Once()
-				s.mockConfig.EXPECT().GetBool(fmt.Sprintf("database.connections.%s.singular", s.connection)).Return(singular).Once()
-				s.mockConfig.EXPECT().GetBool(fmt.Sprintf("database.connections.%s.no_lower_case", s.connection)).Return(true).Once()
-				s.mockConfig.EXPECT().Get(fmt.Sprintf("database.connections.%s.name_replacer", s.connection)).Return(nameReplacer).Once()
-				s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.dsn", s.connection)).Return(dsn).Once()
-				s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.host", s.connection)).Return(host).Once()
-				s.mockConfig.EXPECT().GetInt(fmt.Sprintf("database.connections.%s.port", s.connection)).Return(port).Once()
-				s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.database", s.connection)).Return(database).Once()
-				s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.username", s.connection)).Return(username).Once()
-				s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.password", s.connection)).Return(password).Once()
-				s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.charset", s.connection)).Return(charset).Once()
-				s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.loc", s.connection)).Return(loc).Once()
-			},
+			values:  map[string]any{"no_lower_case": true, "name_replacer": nameReplacer},
 			expectConfigs: []contracts.FullConfig{
 				{
 					Connection:   s.connection,
@@ -226,14 +259,7 @@ func (s *ConfigTestSuite) TestFillDefault() {
 					Password: password,
 				},
 			},
-			setup: func() {
-				s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.prefix", s.connection)).Return(prefix).Once()
-				s.mockConfig.EXPECT().GetBool(fmt.Sprintf("database.connections.%s.singular", s.connection)).Return(singular).Once()
-				s.mockConfig.EXPECT().GetBool(fmt.Sprintf("database.connections.%s.no_lower_case", s.connection)).Return(true).Once()
-				s.mockConfig.EXPECT().Get(fmt.Sprintf("database.connections.%s.name_replacer", s.connection)).Return(nameReplacer).Once()
-				s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.charset", s.connection)).Return(charset).Once()
-				s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.loc", s.connection)).Return(loc).Once()
-			},
+			values: map[string]any{"no_lower_case": true, "name_replacer": nameReplacer},
 			expectConfigs: []contracts.FullConfig{
 				{
 					Connection:   s.connection,
@@ -267,13 +293,8 @@ func (s *ConfigTestSuite) TestFillDefault() {
 					Password: password,
 				},
 			},
+			values: map[string]any{"no_lower_case": true, "name_replacer": nameReplacer, "loc": ""},
 			setup: func() {
-				s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.prefix", s.connection)).Return(prefix).Once()
-				s.mockConfig.EXPECT().GetBool(fmt.Sprintf("database.connections.%s.singular", s.connection)).Return(singular).Once()
-				s.mockConfig.EXPECT().GetBool(fmt.Sprintf("database.connections.%s.no_lower_case", s.connection)).Return(true).Once()
-				s.mockConfig.EXPECT().Get(fmt.Sprintf("database.connections.%s.name_replacer", s.connection)).Return(nameReplacer).Once()
-				s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.charset", s.connection)).Return(charset).Once()
-				s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.loc", s.connection)).Return("").Once()
 				s.mockConfig.EXPECT().GetString("app.timezone", "UTC").Return(loc).Once()
 			},
 			expectConfigs: []contracts.FullConfig{
@@ -297,14 +318,284 @@ func (s *ConfigTestSuite) TestFillDefault() {
 				},
 			},
 		},
+		{
+			name: "success with ssl",
+			configs: []contracts.Config{
+				{
+					Dsn:      dsn,
+					Host:     host,
+					Port:     port,
+					Database: database,
+					Username: username,
+					Password: password,
+					Sslmode:  "verify-identity",
+				},
+			},
+			values: map[string]any{
+				"no_lower_case":   true,
+				"name_replacer":   nameReplacer,
+				"ssl_ca":          "/etc/mysql/ca.pem",
+				"ssl_cert":        "/etc/mysql/client-cert.pem",
+				"ssl_key":         "/etc/mysql/client-key.pem",
+				"ssl_server_name": "mysql.goravel.dev",
+			},
+			expectConfigs: []contracts.FullConfig{
+				{
+					Connection:   s.connection,
+					Driver:       Name,
+					Prefix:       prefix,
+					Singular:     singular,
+					Charset:      charset,
+					Loc:          loc,
+					NoLowerCase:  true,
+					NameReplacer: nameReplacer,
+					Config: contracts.Config{
+						Dsn:           dsn,
+						Database:      database,
+						Host:          host,
+						Port:          port,
+						Username:      username,
+						Password:      password,
+						Sslmode:       "verify-identity",
+						SslCa:         "/etc/mysql/ca.pem",
+						SslCert:       "/etc/mysql/client-cert.pem",
+						SslKey:        "/etc/mysql/client-key.pem",
+						SslServerName: "mysql.goravel.dev",
+					},
+				},
+			},
+		},
+		{
+			name: "success with params",
+			configs: []contracts.Config{
+				{
+					Dsn:      dsn,
+					Host:     host,
+					Port:     port,
+					Database: database,
+					Username: username,
+					Password: password,
+					Params:   map[string]string{"timeout": "10s", "readTimeout": "30s"},
+				},
+			},
+			values: map[string]any{
+				"no_lower_case": true,
+				"name_replacer": nameReplacer,
+				"params":        map[string]any{"timeout": "5s", "writeTimeout": "30s"},
+			},
+			expectConfigs: []contracts.FullConfig{
+				{
+					Connection:   s.connection,
+					Driver:       Name,
+					Prefix:       prefix,
+					Singular:     singular,
+					Charset:      charset,
+					Loc:          loc,
+					NoLowerCase:  true,
+					NameReplacer: nameReplacer,
+					Config: contracts.Config{
+						Dsn:      dsn,
+						Database: database,
+						Host:     host,
+						Port:     port,
+						Username: username,
+						Password: password,
+						Params:   map[string]string{"timeout": "10s", "readTimeout": "30s", "writeTimeout": "30s"},
+					},
+				},
+			},
+		},
+		{
+			name: "success with pool",
+			configs: []contracts.Config{
+				{
+					Dsn:      dsn,
+					Host:     host,
+					Port:     port,
+					Database: database,
+					Username: username,
+					Password: password,
+					Pool:     contracts.Pool{MaxOpenConns: 200, ConnMaxIdleTime: time.Minute},
+				},
+			},
+			values: map[string]any{
+				"no_lower_case":          true,
+				"name_replacer":          nameReplacer,
+				"pool.max_idle_conns":    20,
+				"pool.conn_max_lifetime": time.Duration(3600),
+			},
+			expectConfigs: []contracts.FullConfig{
+				{
+					Connection:   s.connection,
+					Driver:       Name,
+					Prefix:       prefix,
+					Singular:     singular,
+					Charset:      charset,
+					Loc:          loc,
+					NoLowerCase:  true,
+					NameReplacer: nameReplacer,
+					Config: contracts.Config{
+						Dsn:      dsn,
+						Database: database,
+						Host:     host,
+						Port:     port,
+						Username: username,
+						Password: password,
+						Pool: contracts.Pool{
+							MaxOpenConns:    200,
+							MaxIdleConns:    20,
+							ConnMaxLifetime: time.Hour,
+							ConnMaxIdleTime: time.Minute,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "success with weight, replica check and retry",
+			configs: []contracts.Config{
+				{
+					Dsn:      dsn,
+					Host:     host,
+					Port:     port,
+					Database: database,
+					Username: username,
+					Password: password,
+					Weight:   3,
+				},
+			},
+			values: map[string]any{
+				"no_lower_case":          true,
+				"name_replacer":          nameReplacer,
+				"replica_check.interval": time.Duration(5),
+				"replica_check.max_lag":  time.Duration(30),
+				"retry.max_attempts":     5,
+				"retry.backoff":          time.Duration(100),
+				"retry.codes":            []any{1213, "1205"},
+			},
+			expectConfigs: []contracts.FullConfig{
+				{
+					Connection:   s.connection,
+					Driver:       Name,
+					Prefix:       prefix,
+					Singular:     singular,
+					Charset:      charset,
+					Loc:          loc,
+					NoLowerCase:  true,
+					NameReplacer: nameReplacer,
+					ReplicaCheck: contracts.ReplicaCheck{
+						Interval: 5 * time.Second,
+						MaxLag:   30 * time.Second,
+					},
+					Retry: contracts.Retry{
+						MaxAttempts: 5,
+						Backoff:     100 * time.Millisecond,
+						Codes:       []uint16{1213, 1205},
+					},
+					Config: contracts.Config{
+						Dsn:      dsn,
+						Database: database,
+						Host:     host,
+						Port:     port,
+						Username: username,
+						Password: password,
+						Weight:   3,
+					},
+				},
+			},
+		},
+		{
+			name: "success with schema defaults",
+			configs: []contracts.Config{
+				{
+					Dsn:      dsn,
+					Host:     host,
+					Port:     port,
+					Database: database,
+					Username: username,
+					Password: password,
+				},
+			},
+			values: map[string]any{
+				"no_lower_case":        true,
+				"name_replacer":        nameReplacer,
+				"coalesce_alters":      true,
+				"collation":            "utf8mb4_0900_ai_ci",
+				"engine":               "InnoDB",
+				"online_ddl.algorithm": "inplace",
+				"online_ddl.strict":    true,
+				"uuid.binary":          true,
+				"uuid.swap_flag":       true,
+			},
+			expectConfigs: []contracts.FullConfig{
+				{
+					Connection:     s.connection,
+					Driver:         Name,
+					Prefix:         prefix,
+					Singular:       singular,
+					Charset:        charset,
+					CoalesceAlters: true,
+					Collation:      "utf8mb4_0900_ai_ci",
+					Engine:         "InnoDB",
+					OnlineDDL: contracts.OnlineDDL{
+						Algorithm: "inplace",
+						Strict:    true,
+					},
+					Uuid: contracts.Uuid{
+						Binary:   true,
+						SwapFlag: true,
+					},
+					Loc:          loc,
+					NoLowerCase:  true,
+					NameReplacer: nameReplacer,
+					Config: contracts.Config{
+						Dsn:      dsn,
+						Database: database,
+						Host:     host,
+						Port:     port,
+						Username: username,
+						Password: password,
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
 		s.Run(test.name, func() {
-			test.setup()
+			for _, config := range test.configs {
+				s.expectFillDefault(config, test.values)
+			}
+			if test.setup != nil {
+				test.setup()
+			}
 			configs := s.config.fillDefault(test.configs)
 
 			s.Equal(test.expectConfigs, configs)
 		})
 	}
 }
+
+func (s *ConfigTestSuite) TestSession() {
+	s.mockConfig.EXPECT().Get(fmt.Sprintf("database.connections.%s.session", s.connection)).Return(nil).Once()
+	s.Equal(contracts.Session{}, s.config.session())
+
+	session := contracts.Session{TimeZone: "+00:00"}
+	s.mockConfig.EXPECT().Get(fmt.Sprintf("database.connections.%s.session", s.connection)).Return(session).Once()
+	s.Equal(session, s.config.session())
+
+	s.mockConfig.EXPECT().Get(fmt.Sprintf("database.connections.%s.session", s.connection)).Return(map[string]any{
+		"sql_mode":  "TRADITIONAL",
+		"time_zone": "+00:00",
+	}).Once()
+	s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.session.sql_mode", s.connection)).Return("TRADITIONAL").Once()
+	s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.session.time_zone", s.connection)).Return("+00:00").Once()
+	s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.session.transaction_isolation", s.connection)).Return("").Once()
+	s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.session.collation", s.connection)).Return("").Once()
+	s.mockConfig.EXPECT().Get(fmt.Sprintf("database.connections.%s.session.statements", s.connection)).Return([]any{"SET @goravel = 1"}).Once()
+	s.Equal(contracts.Session{
+		SqlMode:    "TRADITIONAL",
+		TimeZone:   "+00:00",
+		Statements: []string{"SET @goravel = 1"},
+	}, s.config.session())
+}
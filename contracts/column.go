@@ -0,0 +1,25 @@
+package contracts
+
+import (
+	"github.com/goravel/framework/contracts/database/driver"
+)
+
+// ColumnDefinition The MySQL only attributes of a column that driver.ColumnDefinition doesn't support,
+// Grammar reads them via type assertion.
+type ColumnDefinition interface {
+	driver.ColumnDefinition
+	// GetAlgorithm returns the ALGORITHM of the alter table statement of the column
+	GetAlgorithm() string
+	// GetCharset returns the charset value
+	GetCharset() string
+	// GetCollation returns the collation value
+	GetCollation() string
+	// GetLock returns the LOCK of the alter table statement of the column
+	GetLock() string
+	// GetSrid returns the SRID of the spatial column
+	GetSrid() int
+	// GetStoredAs returns the expression of the stored generated column
+	GetStoredAs() string
+	// GetVirtualAs returns the expression of the virtual generated column
+	GetVirtualAs() string
+}
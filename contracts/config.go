@@ -1,6 +1,8 @@
 package contracts
 
 import (
+	"time"
+
 	contractsconfig "github.com/goravel/framework/contracts/config"
 )
 
@@ -24,17 +26,114 @@ type Config struct {
 	Database string
 	Username string
 	Password string
+	// Socket The path of the unix socket, Host and Port are ignored when it's set
+	Socket string
+	// Pool The connection pool of the node, it overrides the connection level one
+	Pool Pool
+	// Params The extra go-sql-driver DSN parameters, they override the connection level ones
+	Params map[string]string
+	// Sslmode The TLS mode: disabled, preferred, required, verify-ca or verify-identity
+	Sslmode string
+	// SslCa The path of the CA certificate file
+	SslCa string
+	// SslCert The path of the client certificate file
+	SslCert string
+	// SslKey The path of the client key file
+	SslKey string
+	// SslServerName The server name used to verify the certificate, default is Host
+	SslServerName string
+	// Weight The weight of the read node when choosing a reader, default is 1
+	Weight int
+}
+
+// Pool The connection pool configuration, the zero value means using the database/sql default
+type Pool struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// Session The session variables and statements, they are applied to every new physical connection
+type Session struct {
+	// SqlMode The session sql_mode, e.g. TRADITIONAL
+	SqlMode string
+	// TimeZone The session time_zone, e.g. +00:00
+	TimeZone string
+	// TransactionIsolation The session isolation level: READ UNCOMMITTED, READ COMMITTED, REPEATABLE READ or SERIALIZABLE
+	TransactionIsolation string
+	// Collation The collation used by SET NAMES together with the charset of the connection
+	Collation string
+	// Statements The extra statements executed after the variables above are set
+	Statements []string
+}
+
+// ReplicaCheck The health check of the read nodes, the check is disabled when Interval is zero
+type ReplicaCheck struct {
+	// Interval How often the replication status of the read nodes is checked
+	Interval time.Duration
+	// MaxLag The read node is skipped when it's behind the source more than MaxLag, zero means no limit
+	MaxLag time.Duration
+}
+
+// ReadYourWrites The consistency of the reads after writes, it's enabled for the contexts that are returned by
+// mysql.WithReadYourWrites
+type ReadYourWrites struct {
+	// Mode wait: wait on the replica until the writes are replicated, writer: read from the writer, empty disables it
+	Mode string
+	// Timeout How long to wait on the replica before reading from the writer, default is 1 second
+	Timeout time.Duration
+}
+
+// OnlineDDL The default ALGORITHM and LOCK clauses of the alter table statements, they aren't applied to the foreign
+// keys that are added and the tables that are created
+type OnlineDDL struct {
+	// Algorithm instant, inplace or copy, the server decides when it's empty
+	Algorithm string
+	// Lock none, shared or exclusive, the server decides when it's empty
+	Lock string
+	// Strict Fail the statement rather than falling back to a blocking algorithm when Algorithm and Lock aren't set:
+	// "algorithm = instant" is used to add a column, "algorithm = inplace, lock = none" is used for the others
+	Strict bool
+}
+
+// Retry The retry of the transactions on the transient server errors, it's used by mysql.Retrier
+type Retry struct {
+	// MaxAttempts The max attempts including the first one, default is 3
+	MaxAttempts int
+	// Backoff The base backoff between the attempts, it's doubled after each attempt with jitter, default is 50ms
+	Backoff time.Duration
+	// Codes The retryable server error codes, default is 1213 (deadlock) and 1205 (lock wait timeout)
+	Codes []uint16
+}
+
+// Uuid The storage of the UUID columns
+type Uuid struct {
+	// Binary Store the UUID columns as binary(16) rather than char(36), MariaDB 10.7+ uses the native uuid type
+	Binary bool
+	// SwapFlag Swap the time-low and time-high parts when converting the UUID, so the time-based UUIDs (v1) are
+	// stored in order, it requires MySQL 8.0+
+	SwapFlag bool
 }
 
 // FullConfig Fill the default value for Config
 type FullConfig struct {
 	Config
-	Charset      string
-	Connection   string
-	Driver       string
-	Loc          string
-	NameReplacer Replacer
-	NoLowerCase  bool
-	Prefix       string
-	Singular     bool
+	Charset        string
+	CoalesceAlters bool
+	Collation      string
+	Connection     string
+	Driver         string
+	Engine         string
+	Loc            string
+	NameReplacer   Replacer
+	NoLowerCase    bool
+	OnlineDDL      OnlineDDL
+	Prefix         string
+	ReadYourWrites ReadYourWrites
+	ReplicaCheck   ReplicaCheck
+	Retry          Retry
+	Session        Session
+	Singular       bool
+	Uuid           Uuid
 }
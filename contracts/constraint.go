@@ -0,0 +1,13 @@
+package contracts
+
+// DBCheckConstraint A CHECK constraint in information_schema.check_constraints
+type DBCheckConstraint struct {
+	Name       string
+	Expression string
+}
+
+// CheckConstraint A CHECK constraint of a table, Expression is without the parentheses that the server adds.
+type CheckConstraint struct {
+	Name       string
+	Expression string
+}
@@ -0,0 +1,22 @@
+package contracts
+
+// DBIndexPart A key part of an index in information_schema.statistics
+type DBIndexPart struct {
+	Index      string
+	Column     string
+	Expression string
+	Length     int
+	Desc       bool
+	Position   int
+}
+
+// IndexPart A key part of an index, it's a column or an expression (MySQL 8.0.13+). Length is the prefix length of the
+// column, zero means the whole column is indexed. Index is lower case, the same as driver.Index.Name.
+type IndexPart struct {
+	Index      string
+	Column     string
+	Expression string
+	Length     int
+	Desc       bool
+	Position   int
+}
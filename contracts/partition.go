@@ -0,0 +1,22 @@
+package contracts
+
+// DBPartition A partition in information_schema.partitions
+type DBPartition struct {
+	Name        string
+	Method      string
+	Expression  string
+	Description string
+	Position    int
+	Rows        int64
+}
+
+// Partition A partition of a table, Method is lower case: range, range columns, list, list columns, hash, key, etc.
+// Description is the upper bound of a RANGE partition or the values of a LIST partition.
+type Partition struct {
+	Name        string
+	Method      string
+	Expression  string
+	Description string
+	Position    int
+	Rows        int64
+}
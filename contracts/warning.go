@@ -0,0 +1,8 @@
+package contracts
+
+// DBWarning A warning in the result of SHOW WARNINGS
+type DBWarning struct {
+	Level   string
+	Code    int
+	Message string
+}
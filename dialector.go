@@ -0,0 +1,204 @@
+package mysql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"sync"
+
+	gomysql "github.com/go-sql-driver/mysql"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/goravel/mysql/contracts"
+)
+
+const poolCallback = "goravel:pool"
+
+var _ gorm.Dialector = &Dialector{}
+
+// Dialector wraps the gorm MySQL dialector, the connection pool of the node is opened via a go-sql-driver
+// connector when the dialector is initialized, so the DSN is never formatted and parsed again.
+type Dialector struct {
+	*mysql.Dialector
+	fullConfig        contracts.FullConfig
+	sessionStatements []string
+	// pool The callbacks that restore the pool configuration of the nodes of the connection.
+	pool *poolCallbacks
+	// policy The reader policy that the connection pool is registered to, reader reports the role of the node.
+	policy *ReaderPolicy
+	reader bool
+}
+
+// NewDialector creates the dialector of the node, nil is returned if the node is not configured.
+func NewDialector(fullConfig contracts.FullConfig) (*Dialector, error) {
+	dsnConfig, err := dsnConfig(fullConfig)
+	if err != nil {
+		return nil, err
+	}
+	if dsnConfig == nil {
+		return nil, nil
+	}
+
+	sessionStatements, err := sessionStatements(fullConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateReadYourWrites(fullConfig.ReadYourWrites); err != nil {
+		return nil, err
+	}
+
+	return &Dialector{
+		Dialector: mysql.New(mysql.Config{
+			DSNConfig: dsnConfig,
+		}).(*mysql.Dialector),
+		fullConfig:        fullConfig,
+		sessionStatements: sessionStatements,
+	}, nil
+}
+
+func (r *Dialector) Initialize(db *gorm.DB) error {
+	// The same dialector may be initialized more than once, for example, by gorm and dbresolver,
+	// they share the same connection pool.
+	opened := false
+	if r.Conn == nil {
+		connector, err := gomysql.NewConnector(r.DSNConfig)
+		if err != nil {
+			return err
+		}
+		if len(r.sessionStatements) > 0 {
+			connector = &sessionConnector{Connector: connector, statements: r.sessionStatements}
+		}
+
+		if r.policy != nil && !r.reader {
+			// The framework closes the writer when the application shuts down, the reader policy is closed with it.
+			connector = &closeConnector{Connector: connector, close: r.policy.Close}
+		}
+
+		sqlDB := sql.OpenDB(connector)
+		applyPool(sqlDB, r.fullConfig.Pool)
+		r.Conn = sqlDB
+		opened = true
+	}
+
+	if err := r.Dialector.Initialize(db); err != nil {
+		// gorm closes the connection pool when the initialization fails, a new one should be opened next time.
+		r.Conn = nil
+
+		return err
+	}
+
+	if sqlDB, ok := r.Conn.(*sql.DB); ok && opened && r.pool != nil {
+		if err := r.pool.add(db, sqlDB, r.fullConfig.Pool); err != nil {
+			return err
+		}
+	}
+
+	// The callbacks are registered to the gorm instance that opens the writer first, it's the one used by the
+	// application, the instances opened later by dbresolver share the connection pool only.
+	if opened && !r.reader && r.fullConfig.ReadYourWrites.Mode != "" {
+		if err := (&gtidCallbacks{dialector: r}).register(db); err != nil {
+			return err
+		}
+	}
+
+	if opened && r.policy != nil {
+		if r.reader {
+			r.policy.addReader(r.Conn, r.fullConfig)
+		} else {
+			r.policy.addWriter(r.Conn)
+		}
+	}
+	if sqlDB, ok := r.Conn.(*sql.DB); ok && r.reader && r.policy != nil {
+		db.ConnPool = &readerPool{DB: sqlDB, policy: r.policy}
+	}
+
+	return nil
+}
+
+// Pool returns the connection pool configuration of the node.
+func (r *Dialector) Pool() contracts.Pool {
+	return r.fullConfig.Pool
+}
+
+func applyPool(db *sql.DB, pool contracts.Pool) {
+	if pool.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(pool.MaxOpenConns)
+	}
+	if pool.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(pool.MaxIdleConns)
+	}
+	if pool.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	}
+	if pool.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+	}
+}
+
+// poolCallbacks applies the pool configuration of the nodes again before the first statement. The framework overrides
+// the configuration of every node with the database.pool ones once gorm is opened, there is no hook in between. The
+// callbacks are registered to the gorm instance that opens a node first, it's the one used by the application, the
+// instances opened later by dbresolver share the connection pools only.
+type poolCallbacks struct {
+	mu         sync.Mutex
+	nodes      map[*sql.DB]contracts.Pool
+	once       sync.Once
+	registered bool
+}
+
+func (r *poolCallbacks) add(db *gorm.DB, sqlDB *sql.DB, pool contracts.Pool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nodes == nil {
+		r.nodes = make(map[*sql.DB]contracts.Pool)
+	}
+	r.nodes[sqlDB] = pool
+	if r.registered {
+		return nil
+	}
+	r.registered = true
+
+	callback := db.Callback()
+	if err := callback.Create().Before("*").Register(poolCallback, r.apply); err != nil {
+		return err
+	}
+	if err := callback.Query().Before("*").Register(poolCallback, r.apply); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("*").Register(poolCallback, r.apply); err != nil {
+		return err
+	}
+	if err := callback.Delete().Before("*").Register(poolCallback, r.apply); err != nil {
+		return err
+	}
+	if err := callback.Row().Before("*").Register(poolCallback, r.apply); err != nil {
+		return err
+	}
+
+	return callback.Raw().Before("*").Register(poolCallback, r.apply)
+}
+
+func (r *poolCallbacks) apply(*gorm.DB) {
+	r.once.Do(func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		for sqlDB, pool := range r.nodes {
+			applyPool(sqlDB, pool)
+		}
+	})
+}
+
+// closeConnector calls close when the connection pool is closed, database/sql closes the connector that implements
+// io.Closer together with the pool.
+type closeConnector struct {
+	driver.Connector
+	close func()
+}
+
+func (r *closeConnector) Close() error {
+	r.close()
+
+	return nil
+}
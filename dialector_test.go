@@ -0,0 +1,135 @@
+package mysql
+
+import (
+	"database/sql"
+	"slices"
+	"testing"
+	"time"
+
+	databasedriver "github.com/goravel/framework/database/driver"
+	mocksconfig "github.com/goravel/framework/mocks/config"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/goravel/mysql/contracts"
+	mocks "github.com/goravel/mysql/mocks"
+)
+
+func TestDialector(t *testing.T) {
+	dialector, err := NewDialector(contracts.FullConfig{
+		Config: contracts.Config{
+			Host:     "localhost",
+			Port:     3306,
+			Database: "goravel",
+			Username: "root",
+			Password: "123123",
+			Pool: contracts.Pool{
+				MaxOpenConns:    200,
+				MaxIdleConns:    20,
+				ConnMaxLifetime: time.Hour,
+			},
+		},
+		Session: contracts.Session{
+			TimeZone: "+00:00",
+		},
+	})
+	assert.NoError(t, err)
+	// Avoid connecting to the database when initializing.
+	dialector.SkipInitializeWithVersion = true
+
+	assert.Equal(t, "mysql", dialector.Name())
+	assert.Equal(t, contracts.Pool{MaxOpenConns: 200, MaxIdleConns: 20, ConnMaxLifetime: time.Hour}, dialector.Pool())
+
+	instance, err := gorm.Open(dialector, &gorm.Config{DisableAutomaticPing: true})
+	assert.NoError(t, err)
+
+	db, err := instance.DB()
+	assert.NoError(t, err)
+	assert.Equal(t, 200, db.Stats().MaxOpenConnections)
+	assert.Same(t, db, dialector.Conn)
+
+	// The connection pool is shared when the dialector is initialized again.
+	instance, err = gorm.Open(dialector, &gorm.Config{DisableAutomaticPing: true})
+	assert.NoError(t, err)
+
+	anotherDB, err := instance.DB()
+	assert.NoError(t, err)
+	assert.Same(t, db, anotherDB)
+	assert.NoError(t, db.Close())
+
+	dialector, err = NewDialector(contracts.FullConfig{})
+	assert.NoError(t, err)
+	assert.Nil(t, dialector)
+
+	dialector, err = NewDialector(contracts.FullConfig{
+		Config:  contracts.Config{Host: "localhost", Port: 3306},
+		Session: contracts.Session{TransactionIsolation: "SNAPSHOT"},
+	})
+	assert.EqualError(t, err, InvalidTransactionIsolation.Args("SNAPSHOT").Error())
+	assert.Nil(t, dialector)
+}
+
+func TestDialectorPoolWithBuildGorm(t *testing.T) {
+	// The port is closed, so the connections fail fast.
+	node := func(host string) contracts.FullConfig {
+		return contracts.FullConfig{
+			Config: contracts.Config{
+				Host:     host,
+				Port:     1,
+				Database: "goravel",
+				Pool:     contracts.Pool{MaxOpenConns: 200, MaxIdleConns: 20},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		readers []contracts.FullConfig
+	}{
+		{
+			name: "single writer",
+		},
+		{
+			name:    "writer and readers",
+			readers: []contracts.FullConfig{node("127.0.0.2")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockConfigBuilder := mocks.NewConfigBuilder(t)
+			mockConfigBuilder.EXPECT().Readers().Return(test.readers).Once()
+			mockConfigBuilder.EXPECT().Writers().Return([]contracts.FullConfig{node("127.0.0.1")}).Once()
+
+			pool := (&Mysql{config: mockConfigBuilder}).Pool()
+			var dialectors []*Dialector
+			for _, config := range append(pool.Writers, pool.Readers...) {
+				dialector := config.Dialector.(*Dialector)
+				dialector.SkipInitializeWithVersion = true
+				dialectors = append(dialectors, dialector)
+			}
+
+			mockConfig := mocksconfig.NewConfig(t)
+			mockConfig.EXPECT().GetInt("database.pool.max_idle_conns", 10).Return(10).Once()
+			mockConfig.EXPECT().GetInt("database.pool.max_open_conns", 100).Return(100).Once()
+			mockConfig.EXPECT().GetDuration("database.pool.conn_max_idletime", time.Duration(3600)).Return(3600).Once()
+			mockConfig.EXPECT().GetDuration("database.pool.conn_max_lifetime", time.Duration(3600)).Return(3600).Once()
+
+			instance, _, err := databasedriver.BuildGorm(mockConfig, logger.Discard, pool, "pool_"+test.name, nil)
+			assert.NoError(t, err)
+
+			// The configuration of the node is in effect once the first statement is about to connect.
+			var maxOpenConnections []int
+			assert.NoError(t, instance.Callback().Raw().Before("gorm:raw").Register("test:pool", func(*gorm.DB) {
+				for _, dialector := range dialectors {
+					maxOpenConnections = append(maxOpenConnections, dialector.Conn.(*sql.DB).Stats().MaxOpenConnections)
+				}
+			}))
+			assert.Error(t, instance.Exec("select 1").Error)
+			assert.Equal(t, slices.Repeat([]int{200}, len(dialectors)), maxOpenConnections)
+
+			databasedriver.CloseConnections()
+		})
+	}
+}
@@ -25,6 +25,15 @@ type Docker struct {
 }
 
 func NewDocker(config contracts.ConfigBuilder, process contractsprocess.Process, database, username, password string) *Docker {
+	return newDocker("mysql", config, process, database, username, password)
+}
+
+// NewMariadbDocker returns the Docker of the MariaDB image, the image accepts the MYSQL_* variables as well.
+func NewMariadbDocker(config contracts.ConfigBuilder, process contractsprocess.Process, database, username, password string) *Docker {
+	return newDocker("mariadb", config, process, database, username, password)
+}
+
+func newDocker(repository string, config contracts.ConfigBuilder, process contractsprocess.Process, database, username, password string) *Docker {
 	env := []string{
 		"MYSQL_ROOT_PASSWORD=" + password,
 		"MYSQL_DATABASE=" + database,
@@ -44,8 +53,10 @@ func NewDocker(config contracts.ConfigBuilder, process contractsprocess.Process,
 			Username: username,
 			Password: password,
 		},
+		// The socket of the container isn't bind mounted to the host, contractsdocker.Image has no volume option and
+		// its Args are passed to the container command instead of docker run, the socket DSN is covered by unit tests.
 		imageDriver: testingdocker.NewImageDriver(contractsdocker.Image{
-			Repository:   "mysql",
+			Repository:   repository,
 			Tag:          "latest",
 			Env:          env,
 			ExposedPorts: []string{"3306"},
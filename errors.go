@@ -1,8 +1,136 @@
 package mysql
 
-import "github.com/goravel/framework/errors"
+import (
+	"database/sql/driver"
+	stderrors "errors"
+	"strings"
 
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/goravel/framework/errors"
+)
+
+var (
+	FailedToGenerateDSN               = errors.New("failed to generate DSN, please check the database configuration")
+	ConfigNotFound                    = errors.New("not found database configuration")
+	FailedToBuildDialector            = errors.New("failed to build the dialector of connection %s: %v")
+	InvalidDsnParam                   = errors.New("invalid DSN parameter %s, it's not supported by the MySQL driver")
+	FailedToExecuteSessionStatement   = errors.New("failed to execute the session statement %s: %v")
+	SessionStatementsNotSupported     = errors.New("the connection does not support executing the session statements")
+	InvalidSessionValue               = errors.New("invalid session value %s, the backslash is not allowed")
+	InvalidTransactionIsolation       = errors.New("invalid transaction isolation %s, only READ UNCOMMITTED, READ COMMITTED, REPEATABLE READ and SERIALIZABLE are supported")
+	InvalidSslmode                    = errors.New("invalid sslmode %s, only disabled, preferred, required, verify-ca and verify-identity are supported")
+	FailedToLoadSslCa                 = errors.New("failed to load ssl ca %s: %v")
+	FailedToLoadSslCert               = errors.New("failed to load ssl cert %s and key %s: %v")
+	FailedToRegisterTLS               = errors.New("failed to register tls config %s: %v")
+	SslCaRequired                     = errors.New("ssl ca is required when sslmode is %s")
+	ReplicationNotRunning             = errors.New("the replication is not running")
+	ReplicaLagTooHigh                 = errors.New("the replica is %s behind the source, it exceeds the max lag %s")
+	InvalidReadYourWritesMode         = errors.New("invalid read_your_writes mode %s, only wait and writer are supported")
+	FailedToDumpSchema                = errors.New("failed to dump the schema: %s")
+	FailedToLoadSchema                = errors.New("failed to load the schema %s: %s")
+	ReturningNotSupported             = errors.New("the RETURNING clause of the insert statements requires MariaDB 10.5+, the current version is %s")
+	UpsertColumnsMismatch             = errors.New("the row %d of the upsert has different columns from the first row")
+	UpsertRowsRequired                = errors.New("the rows of the upsert are required")
+	CheckConstraintNotSupported       = errors.New("the CHECK constraint is ignored by %s %s, it requires MySQL 8.0.16+ or MariaDB 10.2.1+")
+	CheckConstraintsNotListed         = errors.New("the CHECK constraints can't be listed on %s %s, it requires MySQL 8.0.16+ or MariaDB 10.2.22+")
+	GeneratedColumnRenameNotSupported = errors.New("the generated column %s can't be renamed on %s %s, it requires MySQL 8.0.3+ or MariaDB 10.5.2+")
+)
+
+// The classified server errors, they are matched via errors.Is on the result of ClassifyError.
 var (
-	FailedToGenerateDSN = errors.New("failed to generate DSN, please check the database configuration")
-	ConfigNotFound      = errors.New("not found database configuration")
+	DuplicateKey        = errors.New("duplicate key")
+	Deadlock            = errors.New("deadlock found when trying to get lock")
+	LockWaitTimeout     = errors.New("lock wait timeout exceeded")
+	ForeignKeyViolation = errors.New("foreign key constraint fails")
+	ReadOnly            = errors.New("the server is read only")
+	ConnectionGoneAway  = errors.New("the server has gone away")
 )
+
+// serverErrors The server error codes of MySQL and MariaDB and the sentinel errors they are classified as.
+var serverErrors = map[uint16]error{
+	1062: DuplicateKey,        // ER_DUP_ENTRY
+	1586: DuplicateKey,        // ER_DUP_ENTRY_WITH_KEY_NAME
+	1213: Deadlock,            // ER_LOCK_DEADLOCK
+	1205: LockWaitTimeout,     // ER_LOCK_WAIT_TIMEOUT
+	1216: ForeignKeyViolation, // ER_NO_REFERENCED_ROW
+	1217: ForeignKeyViolation, // ER_ROW_IS_REFERENCED
+	1451: ForeignKeyViolation, // ER_ROW_IS_REFERENCED_2
+	1452: ForeignKeyViolation, // ER_NO_REFERENCED_ROW_2
+	1290: ReadOnly,            // ER_OPTION_PREVENTS_STATEMENT, e.g. --read-only
+	1792: ReadOnly,            // ER_CANT_EXECUTE_IN_READ_ONLY_TRANSACTION
+	1053: ConnectionGoneAway,  // ER_SERVER_SHUTDOWN
+	1927: ConnectionGoneAway,  // ER_CONNECTION_KILLED of MariaDB
+	2006: ConnectionGoneAway,  // CR_SERVER_GONE_ERROR
+	2013: ConnectionGoneAway,  // CR_SERVER_LOST
+	4031: ConnectionGoneAway,  // ER_CLIENT_INTERACTION_TIMEOUT
+}
+
+// ServerError The classified server error, it matches both the sentinel error and the original error via errors.Is
+// and errors.As, for example, errors.Is(err, mysql.DuplicateKey) and errors.As(err, &mysqlError).
+type ServerError struct {
+	// Key The name of the duplicated key, it's only set for DuplicateKey.
+	Key string
+
+	kind error
+	err  error
+}
+
+func (r *ServerError) Error() string {
+	return r.err.Error()
+}
+
+func (r *ServerError) Unwrap() []error {
+	return []error{r.kind, r.err}
+}
+
+// ClassifyError classifies the error returned by the server, the error is returned as it is if it isn't
+// a known one.
+func ClassifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var serverError *ServerError
+	if stderrors.As(err, &serverError) {
+		return err
+	}
+
+	var mysqlError *gomysql.MySQLError
+	if stderrors.As(err, &mysqlError) {
+		kind, ok := serverErrors[mysqlError.Number]
+		if !ok {
+			return err
+		}
+
+		serverError = &ServerError{kind: kind, err: err}
+		if kind == DuplicateKey {
+			serverError.Key = duplicateKey(mysqlError.Message)
+		}
+
+		return serverError
+	}
+
+	// go-sql-driver reports the lost connections as the client errors.
+	if stderrors.Is(err, gomysql.ErrInvalidConn) || stderrors.Is(err, driver.ErrBadConn) {
+		return &ServerError{kind: ConnectionGoneAway, err: err}
+	}
+
+	return err
+}
+
+// duplicateKey parses the key name from the message: Duplicate entry '%s' for key '%s', the entry might
+// contain the quotes, so the key is found from the end. MySQL 8 qualifies the key with the table name, it's
+// stripped, so the key is the same as the one of MySQL 5.7 and MariaDB.
+func duplicateKey(message string) string {
+	index := strings.LastIndex(message, " for key '")
+	if index == -1 {
+		return ""
+	}
+
+	key := strings.TrimSuffix(message[index+len(" for key '"):], "'")
+	if index := strings.LastIndex(key, "."); index != -1 {
+		key = key[index+1:]
+	}
+
+	return key
+}
@@ -0,0 +1,113 @@
+package mysql
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		expectErr error
+		expectKey string
+	}{
+		{
+			name:      "duplicate key",
+			err:       &gomysql.MySQLError{Number: 1062, Message: "Duplicate entry 'hello@goravel.dev' for key 'users.users_email_unique'"},
+			expectErr: DuplicateKey,
+			expectKey: "users_email_unique",
+		},
+		{
+			name:      "duplicate key of MySQL 5.7",
+			err:       &gomysql.MySQLError{Number: 1062, Message: "Duplicate entry 'hello@goravel.dev' for key 'users_email_unique'"},
+			expectErr: DuplicateKey,
+			expectKey: "users_email_unique",
+		},
+		{
+			name:      "duplicate key with quotes in the entry",
+			err:       &gomysql.MySQLError{Number: 1062, Message: "Duplicate entry 'it's for key 'a'' for key 'PRIMARY'"},
+			expectErr: DuplicateKey,
+			expectKey: "PRIMARY",
+		},
+		{
+			name:      "wrapped duplicate key",
+			err:       fmt.Errorf("failed to create user: %w", &gomysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 'PRIMARY'"}),
+			expectErr: DuplicateKey,
+			expectKey: "PRIMARY",
+		},
+		{
+			name:      "deadlock",
+			err:       &gomysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock; try restarting transaction"},
+			expectErr: Deadlock,
+		},
+		{
+			name:      "lock wait timeout",
+			err:       &gomysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded; try restarting transaction"},
+			expectErr: LockWaitTimeout,
+		},
+		{
+			name:      "foreign key violation of parent row",
+			err:       &gomysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row: a foreign key constraint fails"},
+			expectErr: ForeignKeyViolation,
+		},
+		{
+			name:      "foreign key violation of child row",
+			err:       &gomysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails"},
+			expectErr: ForeignKeyViolation,
+		},
+		{
+			name:      "read only",
+			err:       &gomysql.MySQLError{Number: 1290, Message: "The MySQL server is running with the --read-only option so it cannot execute this statement"},
+			expectErr: ReadOnly,
+		},
+		{
+			name:      "read only transaction",
+			err:       &gomysql.MySQLError{Number: 1792, Message: "Cannot execute statement in a READ ONLY transaction."},
+			expectErr: ReadOnly,
+		},
+		{
+			name:      "gone away",
+			err:       &gomysql.MySQLError{Number: 2006, Message: "MySQL server has gone away"},
+			expectErr: ConnectionGoneAway,
+		},
+		{
+			name:      "invalid connection",
+			err:       gomysql.ErrInvalidConn,
+			expectErr: ConnectionGoneAway,
+		},
+		{
+			name:      "bad connection",
+			err:       driver.ErrBadConn,
+			expectErr: ConnectionGoneAway,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ClassifyError(test.err)
+
+			assert.ErrorIs(t, err, test.expectErr)
+			assert.ErrorIs(t, err, test.err)
+			assert.Equal(t, test.err.Error(), err.Error())
+
+			var serverError *ServerError
+			assert.True(t, errors.As(err, &serverError))
+			assert.Equal(t, test.expectKey, serverError.Key)
+
+			// The classified error is returned as it is.
+			assert.Same(t, serverError, ClassifyError(err))
+		})
+	}
+
+	assert.Nil(t, ClassifyError(nil))
+
+	unknown := &gomysql.MySQLError{Number: 1146, Message: "Table 'goravel.users' doesn't exist"}
+	assert.Same(t, unknown, ClassifyError(unknown))
+	assert.NotErrorIs(t, ClassifyError(unknown), DuplicateKey)
+}
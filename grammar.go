@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"cmp"
 	"fmt"
 	"reflect"
 	"slices"
@@ -15,19 +16,38 @@ import (
 	"github.com/goravel/framework/support/collect"
 	"github.com/spf13/cast"
 	"gorm.io/gorm/clause"
+
+	"github.com/goravel/mysql/contracts"
 )
 
 var _ driver.Grammar = &Grammar{}
 
 type Grammar struct {
 	attributeCommands []string
+	coalesceAlters    bool
+	collation         string
 	database          string
-	modifiers         []func(driver.Blueprint, driver.ColumnDefinition) string
-	name              string
-	prefix            string
-	serials           []string
-	version           string
-	wrap              *schema.Wrap
+	// dialect The grammar that the column types and the versions are resolved on, it's the MariaDB grammar if it embeds
+	// this one.
+	dialect   dialect
+	engine    string
+	modifiers []func(driver.Blueprint, driver.ColumnDefinition) string
+	name      string
+	onlineDDL contracts.OnlineDDL
+	prefix    string
+	serials   []string
+	uuid      contracts.Uuid
+	version   string
+	wrap      *schema.Wrap
+}
+
+// dialect The hooks of the statements that are different between MySQL and MariaDB, MariadbGrammar overrides them.
+type dialect interface {
+	driver.Grammar
+	// requiredVersion returns the version of the server that a feature requires, nil means it isn't supported.
+	requiredVersion(mysql, mariadb *semver.Version) *semver.Version
+	// spatialSridKeyword returns the keyword that declares the SRID of a spatial column.
+	spatialSridKeyword() string
 }
 
 func NewGrammar(database, prefix, version, name string) *Grammar {
@@ -40,12 +60,17 @@ func NewGrammar(database, prefix, version, name string) *Grammar {
 		version:           version,
 		wrap:              schema.NewWrap(prefix),
 	}
+	grammar.dialect = grammar
 	grammar.wrap.SetValueWrapper(func(s string) string {
 		return "`" + strings.ReplaceAll(s, "`", "``") + "`"
 	})
 	grammar.modifiers = []func(driver.Blueprint, driver.ColumnDefinition) string{
 		// The sort should not be changed, it effects the SQL output
 		grammar.ModifyUnsigned,
+		grammar.ModifyCharset,
+		grammar.ModifyCollation,
+		grammar.ModifyVirtualAs,
+		grammar.ModifyStoredAs,
 		grammar.ModifyNullable,
 		grammar.ModifyDefault,
 		grammar.ModifyOnUpdate,
@@ -59,15 +84,62 @@ func NewGrammar(database, prefix, version, name string) *Grammar {
 }
 
 func (r *Grammar) CompileAdd(blueprint driver.Blueprint, command *driver.Command) string {
-	return fmt.Sprintf("alter table %s add %s", r.wrap.Table(blueprint.GetTableName()), r.getColumn(blueprint, command.Column))
+	return r.compileAlter(blueprint, command, "add "+r.getColumn(blueprint, command.Column), true)
+}
+
+// CompileAddPartitions adds partitions to a RANGE or LIST partitioned table, the statements of the partitions
+// can be executed via facades.Schema().Sql().
+func (r *Grammar) CompileAddPartitions(table string, partitions ...Partition) string {
+	return fmt.Sprintf("alter table %s add partition %s", r.wrap.Table(table), r.compilePartitionDefinitions(partitions))
+}
+
+// CompileBinToUuid compiles the UUID column to the UUID string according to the UUID storage of the connection.
+func (r *Grammar) CompileBinToUuid(column string) string {
+	column = r.wrap.Column(column)
+	if !r.uuid.Binary {
+		return column
+	}
+	if !r.versionAtLeast(semver.New(8, 0, 0, "", ""), nil) {
+		return fmt.Sprintf("lower(insert(insert(insert(insert(hex(%s), 9, 0, '-'), 14, 0, '-'), 19, 0, '-'), 24, 0, '-'))", column)
+	}
+	if r.uuid.SwapFlag {
+		return fmt.Sprintf("bin_to_uuid(%s, 1)", column)
+	}
+
+	return fmt.Sprintf("bin_to_uuid(%s)", column)
 }
 
 func (r *Grammar) CompileChange(blueprint driver.Blueprint, command *driver.Command) []string {
 	return []string{
-		fmt.Sprintf("alter table %s modify %s", r.wrap.Table(blueprint.GetTableName()), r.getColumn(blueprint, command.Column)),
+		r.compileAlter(blueprint, command, "modify "+r.getColumn(blueprint, command.Column), false),
 	}
 }
 
+// CompileCheck adds a CHECK constraint to the table, the expression is raw SQL, e.g. "`age` >= 18". The servers that
+// parse but ignore the constraint return an error.
+func (r *Grammar) CompileCheck(table, name, expression string) (string, error) {
+	if err := r.checkConstraintSupported(); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("alter table %s add constraint %s check (%s)", r.wrap.Table(table), r.wrap.Column(name), expression), nil
+}
+
+// CompileCheckConstraints lists the CHECK constraints of the table, information_schema.check_constraints is added by
+// MySQL 8.0.16 and MariaDB 10.2.22.
+func (r *Grammar) CompileCheckConstraints(table string) (string, error) {
+	if !r.versionAtLeast(semver.New(8, 0, 16, "", ""), semver.New(10, 2, 22, "", "")) {
+		return "", CheckConstraintsNotListed.Args(r.name, r.version)
+	}
+
+	return fmt.Sprintf("select cc.constraint_name as `name`, cc.check_clause as `expression` "+
+		"from information_schema.check_constraints as cc "+
+		"join information_schema.table_constraints as tc "+
+		"on tc.constraint_schema = cc.constraint_schema and tc.constraint_name = cc.constraint_name and tc.constraint_type = 'CHECK' "+
+		"where tc.table_schema = %s and tc.table_name = %s "+
+		"order by cc.constraint_name", r.wrap.Quote(r.database), r.wrap.Quote(r.prefix+table)), nil
+}
+
 func (r *Grammar) CompileColumns(_, table string) (string, error) {
 	table = r.prefix + table
 
@@ -86,18 +158,29 @@ func (r *Grammar) CompileComment(_ driver.Blueprint, _ *driver.Command) string {
 
 func (r *Grammar) CompileCreate(blueprint driver.Blueprint) string {
 	columns := r.getColumns(blueprint)
-	primaryCommand := getCommandByName(blueprint.GetCommands(), "primary")
+	commands := blueprint.GetCommands()
+
+	primaryCommand := getCommandByName(commands, "primary")
 	if primaryCommand != nil {
 		var algorithm string
 		if primaryCommand.Algorithm != "" {
 			algorithm = "using " + primaryCommand.Algorithm
 		}
-		columns = append(columns, fmt.Sprintf("primary key %s(%s)", algorithm, r.wrap.Columnize(primaryCommand.Columns)))
+		columns = append(columns, fmt.Sprintf("primary key %s(%s)", algorithm, r.compileIndexParts(blueprint, primaryCommand)))
 
 		primaryCommand.ShouldBeSkipped = true
 	}
 
-	return fmt.Sprintf("create table %s (%s)", r.wrap.Table(blueprint.GetTableName()), strings.Join(columns, ", "))
+	definition := tableDefinition(blueprint)
+	if definition == nil {
+		definition = &TableDefinition{}
+	}
+
+	return fmt.Sprintf("create table %s (%s)%s%s",
+		r.wrap.Table(blueprint.GetTableName()),
+		strings.Join(columns, ", "),
+		r.compileTableOptions(definition, getCommandByName(commands, schema.CommandTableComment)),
+		r.compilePartitioning(definition.partitioning))
 }
 
 func (r *Grammar) CompileDefault(_ driver.Blueprint, _ *driver.Command) string {
@@ -144,16 +227,26 @@ func (r *Grammar) CompileDropAllViews(_ string, views []driver.View) []string {
 	}
 }
 
-func (r *Grammar) CompileDropColumn(blueprint driver.Blueprint, command *driver.Command) []string {
-	columns := r.wrap.PrefixArray("drop", r.wrap.Columns(command.Columns))
+func (r *Grammar) CompileDropCheck(table, name string) (string, error) {
+	if err := r.checkConstraintSupported(); err != nil {
+		return "", err
+	}
+
+	// MySQL supports DROP CONSTRAINT since 8.0.19.
+	return fmt.Sprintf("alter table %s drop check %s", r.wrap.Table(table), r.wrap.Column(name)), nil
+}
 
+func (r *Grammar) CompileDropColumn(blueprint driver.Blueprint, command *driver.Command) []string {
 	return []string{
-		fmt.Sprintf("alter table %s %s", r.wrap.Table(blueprint.GetTableName()), strings.Join(columns, ", ")),
+		r.compileAlter(blueprint, command, r.dropColumnClause(command), false),
 	}
 }
 
 func (r *Grammar) CompileDropForeign(blueprint driver.Blueprint, command *driver.Command) string {
-	return fmt.Sprintf("alter table %s drop foreign key %s", r.wrap.Table(blueprint.GetTableName()), r.wrap.Column(command.Index))
+	return fmt.Sprintf("alter table %s drop foreign key %s%s",
+		r.wrap.Table(blueprint.GetTableName()),
+		r.wrap.Column(command.Index),
+		r.compileAlterOptions(blueprint, command, false))
 }
 
 func (r *Grammar) CompileDropFullText(blueprint driver.Blueprint, command *driver.Command) string {
@@ -165,11 +258,15 @@ func (r *Grammar) CompileDropIfExists(blueprint driver.Blueprint) string {
 }
 
 func (r *Grammar) CompileDropIndex(blueprint driver.Blueprint, command *driver.Command) string {
-	return fmt.Sprintf("alter table %s drop index %s", r.wrap.Table(blueprint.GetTableName()), r.wrap.Column(command.Index))
+	return r.compileAlter(blueprint, command, "drop index "+r.wrap.Column(command.Index), false)
+}
+
+func (r *Grammar) CompileDropPartitions(table string, partitions ...string) string {
+	return fmt.Sprintf("alter table %s drop partition %s", r.wrap.Table(table), r.compilePartitionNames(partitions))
 }
 
-func (r *Grammar) CompileDropPrimary(blueprint driver.Blueprint, _ *driver.Command) string {
-	return fmt.Sprintf("alter table %s drop primary key", r.wrap.Table(blueprint.GetTableName()))
+func (r *Grammar) CompileDropPrimary(blueprint driver.Blueprint, command *driver.Command) string {
+	return r.compileAlter(blueprint, command, "drop primary key", false)
 }
 
 func (r *Grammar) CompileDropUnique(blueprint driver.Blueprint, command *driver.Command) string {
@@ -180,6 +277,12 @@ func (r *Grammar) CompileEnableForeignKeyConstraints() string {
 	return "SET FOREIGN_KEY_CHECKS=1;"
 }
 
+// CompileExchangePartition swaps the rows of the partition with the rows of a non-partitioned table.
+func (r *Grammar) CompileExchangePartition(table, partition, withTable string) string {
+	return fmt.Sprintf("alter table %s exchange partition %s with table %s",
+		r.wrap.Table(table), r.wrap.Column(partition), r.wrap.Table(withTable))
+}
+
 func (r *Grammar) CompileForeign(blueprint driver.Blueprint, command *driver.Command) string {
 	sql := fmt.Sprintf("alter table %s add constraint %s foreign key (%s) references %s (%s)",
 		r.wrap.Table(blueprint.GetTableName()),
@@ -194,7 +297,7 @@ func (r *Grammar) CompileForeign(blueprint driver.Blueprint, command *driver.Com
 		sql += " on update " + command.OnUpdate
 	}
 
-	return sql
+	return sql + r.compileAlterOptions(blueprint, command, false)
 }
 
 func (r *Grammar) CompileForeignKeys(_, table string) string {
@@ -230,18 +333,7 @@ func (r *Grammar) CompileFullText(blueprint driver.Blueprint, command *driver.Co
 }
 
 func (r *Grammar) CompileIndex(blueprint driver.Blueprint, command *driver.Command) string {
-	var algorithm string
-	if command.Algorithm != "" {
-		algorithm = " using " + command.Algorithm
-	}
-
-	return fmt.Sprintf("alter table %s add %s %s%s(%s)",
-		r.wrap.Table(blueprint.GetTableName()),
-		"index",
-		r.wrap.Column(command.Index),
-		algorithm,
-		r.wrap.Columnize(command.Columns),
-	)
+	return r.compileKey(blueprint, command, "index")
 }
 
 func (r *Grammar) CompileIndexes(_, table string) (string, error) {
@@ -257,7 +349,26 @@ func (r *Grammar) CompileIndexes(_, table string) (string, error) {
 	), nil
 }
 
+// CompileIndexParts returns the key parts of the indexes of a table, the result should be scanned into
+// []contracts.DBIndexPart and processed by Processor.ProcessIndexParts. The expression column is added by MySQL 8.0.13
+// with the functional key parts.
+func (r *Grammar) CompileIndexParts(table string) string {
+	expression := "null"
+	if r.versionAtLeast(semver.New(8, 0, 13, "", ""), nil) {
+		expression = "expression"
+	}
+
+	return fmt.Sprintf("select index_name as `index`, column_name as `column`, %s as `expression`, "+
+		"sub_part as `length`, collation = 'D' as `desc`, seq_in_index as `position` "+
+		"from information_schema.statistics where table_schema = %s and table_name = %s "+
+		"order by index_name, seq_in_index", expression, r.wrap.Quote(r.database), r.wrap.Quote(r.prefix+table))
+}
+
 func (r *Grammar) CompileJsonColumnsUpdate(values map[string]any) (map[string]any, error) {
+	return r.compileJsonColumnsUpdate(values, "cast(? as json)")
+}
+
+func (r *Grammar) compileJsonColumnsUpdate(values map[string]any, cast string) (map[string]any, error) {
 	var (
 		compiled = make(map[string]any)
 		json     = App.GetJson()
@@ -277,12 +388,7 @@ func (r *Grammar) CompileJsonColumnsUpdate(values map[string]any) (map[string]an
 				if err != nil {
 					return nil, err
 				}
-				value = databasedb.Raw("cast(? as json)", string(binding))
-
-				// MariaDB does not support casting to JSON directly
-				if r.name != Name {
-					value = databasedb.Raw("json_extract(?, '$')", string(binding))
-				}
+				value = databasedb.Raw(cast, string(binding))
 			}
 
 			expr, ok := compiled[column]
@@ -374,13 +480,18 @@ func (r *Grammar) CompilePlaceholderFormat() driver.PlaceholderFormat {
 	return nil
 }
 
-func (r *Grammar) CompilePrimary(blueprint driver.Blueprint, command *driver.Command) string {
-	var algorithm string
-	if command.Algorithm != "" {
-		algorithm = "using " + command.Algorithm
-	}
+// CompilePartitions returns the partitions of a table, the result should be scanned into []contracts.DBPartition
+// and processed by Processor.ProcessPartitions.
+func (r *Grammar) CompilePartitions(table string) string {
+	return fmt.Sprintf("select partition_name as `name`, partition_method as `method`, "+
+		"partition_expression as `expression`, partition_description as `description`, "+
+		"partition_ordinal_position as `position`, table_rows as `rows` "+
+		"from information_schema.partitions where table_schema = %s and table_name = %s and partition_name is not null "+
+		"order by partition_ordinal_position", r.wrap.Quote(r.database), r.wrap.Quote(r.prefix+table))
+}
 
-	return fmt.Sprintf("alter table %s add primary key %s(%s)", r.wrap.Table(blueprint.GetTableName()), algorithm, r.wrap.Columnize(command.Columns))
+func (r *Grammar) CompilePrimary(blueprint driver.Blueprint, command *driver.Command) string {
+	return r.compileAlter(blueprint, command, r.primaryClause(blueprint, command), false)
 }
 
 func (r *Grammar) CompilePrune(_ string) string { return "" }
@@ -402,26 +513,40 @@ func (r *Grammar) CompileRename(blueprint driver.Blueprint, command *driver.Comm
 }
 
 func (r *Grammar) CompileRenameColumn(blueprint driver.Blueprint, command *driver.Command, columns []driver.Column) (string, error) {
-	if v, err := semver.NewVersion(r.version); err == nil {
-		isMariaDB := r.name != Name
-		if (isMariaDB && v.LessThan(semver.New(10, 5, 2, "", ""))) || (!isMariaDB && v.LessThan(semver.New(8, 0, 3, "", ""))) {
-			return r.compileLegacyRenameColumn(blueprint, command, columns)
-		}
+	if r.legacyRenameColumn() {
+		return r.compileLegacyRenameColumn(blueprint, command, columns)
 	}
 
-	return fmt.Sprintf("alter table %s rename column %s to %s",
+	return r.compileRenameColumn(blueprint, command), nil
+}
+
+func (r *Grammar) compileRenameColumn(blueprint driver.Blueprint, command *driver.Command) string {
+	return fmt.Sprintf("alter table %s rename column %s to %s%s",
 		r.wrap.Table(blueprint.GetTableName()),
 		r.wrap.Column(command.From),
 		r.wrap.Column(command.To),
-	), nil
+		r.compileAlterOptions(blueprint, command, false),
+	)
 }
 
 func (r *Grammar) CompileRenameIndex(blueprint driver.Blueprint, command *driver.Command, _ []driver.Index) []string {
 	return []string{
-		fmt.Sprintf("alter table %s rename index %s to %s", r.wrap.Table(blueprint.GetTableName()), r.wrap.Column(command.From), r.wrap.Column(command.To)),
+		r.compileAlter(blueprint, command, r.renameIndexClause(command), false),
 	}
 }
 
+// CompileReorganizePartitions merges or splits the partitions into the new partitions without losing rows.
+func (r *Grammar) CompileReorganizePartitions(table string, partitions []string, into ...Partition) string {
+	return fmt.Sprintf("alter table %s reorganize partition %s into %s",
+		r.wrap.Table(table), r.compilePartitionNames(partitions), r.compilePartitionDefinitions(into))
+}
+
+func (r *Grammar) CompileRoutines(database string) string {
+	return fmt.Sprintf("select routine_name as `name`, routine_type as `type` "+
+		"from information_schema.routines where routine_schema = %s "+
+		"order by routine_type, routine_name", r.wrap.Quote(database))
+}
+
 func (r *Grammar) CompileSharedLock(builder sq.SelectBuilder, conditions *driver.Conditions) sq.SelectBuilder {
 	if conditions.SharedLock != nil && *conditions.SharedLock {
 		builder = builder.Suffix("FOR SHARE")
@@ -434,6 +559,12 @@ func (r *Grammar) CompileSharedLockForGorm() clause.Expression {
 	return clause.Locking{Strength: "SHARE"}
 }
 
+// CompileSpatialIndex adds a SPATIAL index, the columns should be NOT NULL spatial columns with an SRID. An index
+// of the blueprint is compiled as a SPATIAL index if it's marked via TableDefinition.SpatialIndex.
+func (r *Grammar) CompileSpatialIndex(blueprint driver.Blueprint, command *driver.Command) string {
+	return r.compileKey(blueprint, command, "spatial index")
+}
+
 func (r *Grammar) CompileTables(database string) string {
 	return fmt.Sprintf("select table_name as `name`, (data_length + index_length) as `size`, "+
 		"table_comment as `comment`, engine as `engine`, table_collation as `collation` "+
@@ -442,10 +573,17 @@ func (r *Grammar) CompileTables(database string) string {
 }
 
 func (r *Grammar) CompileTableComment(blueprint driver.Blueprint, command *driver.Command) string {
-	return fmt.Sprintf("alter table %s comment = '%s'",
-		r.wrap.Table(blueprint.GetTableName()),
-		strings.ReplaceAll(command.Value, "'", "''"),
-	)
+	return r.compileAlter(blueprint, command, r.tableCommentClause(command), false)
+}
+
+func (r *Grammar) CompileTriggers(database string) string {
+	return fmt.Sprintf("select trigger_name as `name`, event_object_table as `table` "+
+		"from information_schema.triggers where trigger_schema = %s "+
+		"order by event_object_table, action_order, trigger_name", r.wrap.Quote(database))
+}
+
+func (r *Grammar) CompileTruncatePartitions(table string, partitions ...string) string {
+	return fmt.Sprintf("alter table %s truncate partition %s", r.wrap.Table(table), r.compilePartitionNames(partitions))
 }
 
 func (r *Grammar) CompileTypes() string {
@@ -456,6 +594,26 @@ func (r *Grammar) CompileUnique(blueprint driver.Blueprint, command *driver.Comm
 	return r.compileKey(blueprint, command, "unique")
 }
 
+// CompileUuidToBin compiles the UUID string to the storage of the UUID columns, the UUID is bound rather than
+// interpolated, the result can be passed to Where:
+//
+//	sql, args := grammar.CompileUuidToBin("6ccd780c-baba-1026-9564-5b8c656024db")
+//	facades.DB().Table("users").Where("id = "+sql, args...).First(&user)
+func (r *Grammar) CompileUuidToBin(uuid string) (string, []any) {
+	args := []any{uuid}
+	if !r.uuid.Binary {
+		return "?", args
+	}
+	if !r.versionAtLeast(semver.New(8, 0, 0, "", ""), nil) {
+		return "unhex(replace(?, '-', ''))", args
+	}
+	if r.uuid.SwapFlag {
+		return "uuid_to_bin(?, 1)", args
+	}
+
+	return "uuid_to_bin(?)", args
+}
+
 func (r *Grammar) CompileVersion() string {
 	return "SELECT VERSION() AS value;"
 }
@@ -478,6 +636,22 @@ func (r *Grammar) ModifyAfter(_ driver.Blueprint, column driver.ColumnDefinition
 	return ""
 }
 
+func (r *Grammar) ModifyCharset(_ driver.Blueprint, column driver.ColumnDefinition) string {
+	if definition := columnDefinition(column); definition != nil && definition.GetCharset() != "" {
+		return " character set " + definition.GetCharset()
+	}
+
+	return ""
+}
+
+func (r *Grammar) ModifyCollation(_ driver.Blueprint, column driver.ColumnDefinition) string {
+	if definition := columnDefinition(column); definition != nil && definition.GetCollation() != "" {
+		return " collate " + definition.GetCollation()
+	}
+
+	return ""
+}
+
 func (r *Grammar) ModifyComment(_ driver.Blueprint, column driver.ColumnDefinition) string {
 	if comment := column.GetComment(); comment != "" {
 		// Escape special characters to prevent SQL injection
@@ -541,6 +715,17 @@ func (r *Grammar) ModifyOnUpdate(_ driver.Blueprint, column driver.ColumnDefinit
 	return ""
 }
 
+// ModifyStoredAs declares a stored generated column:
+//
+//	mysql.Column(table.Integer("total")).StoredAs("`price` * `quantity`")
+func (r *Grammar) ModifyStoredAs(_ driver.Blueprint, column driver.ColumnDefinition) string {
+	if definition := columnDefinition(column); definition != nil && definition.GetStoredAs() != "" {
+		return fmt.Sprintf(" as (%s) stored", definition.GetStoredAs())
+	}
+
+	return ""
+}
+
 func (r *Grammar) ModifyUnsigned(_ driver.Blueprint, column driver.ColumnDefinition) string {
 	if column.GetUnsigned() {
 		return " unsigned"
@@ -549,10 +734,41 @@ func (r *Grammar) ModifyUnsigned(_ driver.Blueprint, column driver.ColumnDefinit
 	return ""
 }
 
+// ModifyVirtualAs declares a virtual generated column:
+//
+//	mysql.Column(table.Integer("total")).VirtualAs("`price` * `quantity`")
+func (r *Grammar) ModifyVirtualAs(_ driver.Blueprint, column driver.ColumnDefinition) string {
+	if definition := columnDefinition(column); definition != nil && definition.GetVirtualAs() != "" {
+		return fmt.Sprintf(" as (%s)", definition.GetVirtualAs())
+	}
+
+	return ""
+}
+
 func (r *Grammar) TypeBigInteger(_ driver.ColumnDefinition) string {
 	return "bigint"
 }
 
+func (r *Grammar) TypeBinary(column driver.ColumnDefinition) string {
+	if length := columnLength(column); length > 0 {
+		return fmt.Sprintf("binary(%d)", length)
+	}
+
+	return "binary(255)"
+}
+
+func (r *Grammar) TypeBit(column driver.ColumnDefinition) string {
+	if length := columnLength(column); length > 0 {
+		return fmt.Sprintf("bit(%d)", length)
+	}
+
+	return "bit"
+}
+
+func (r *Grammar) TypeBlob(_ driver.ColumnDefinition) string {
+	return "blob"
+}
+
 func (r *Grammar) TypeBoolean(_ driver.ColumnDefinition) string {
 	return "tinyint(1)"
 }
@@ -610,10 +826,22 @@ func (r *Grammar) TypeFloat(column driver.ColumnDefinition) string {
 	return "float"
 }
 
+func (r *Grammar) TypeGeometry(column driver.ColumnDefinition) string {
+	return r.spatialType("geometry", column)
+}
+
+func (r *Grammar) TypeGeometryCollection(column driver.ColumnDefinition) string {
+	return r.spatialType("geometrycollection", column)
+}
+
 func (r *Grammar) TypeInteger(_ driver.ColumnDefinition) string {
 	return "int"
 }
 
+func (r *Grammar) TypeIpAddress(_ driver.ColumnDefinition) string {
+	return "varchar(45)"
+}
+
 func (r *Grammar) TypeJson(_ driver.ColumnDefinition) string {
 	return "json"
 }
@@ -622,10 +850,22 @@ func (r *Grammar) TypeJsonb(_ driver.ColumnDefinition) string {
 	return "json"
 }
 
+func (r *Grammar) TypeLineString(column driver.ColumnDefinition) string {
+	return r.spatialType("linestring", column)
+}
+
+func (r *Grammar) TypeLongBlob(_ driver.ColumnDefinition) string {
+	return "longblob"
+}
+
 func (r *Grammar) TypeLongText(_ driver.ColumnDefinition) string {
 	return "longtext"
 }
 
+func (r *Grammar) TypeMediumBlob(_ driver.ColumnDefinition) string {
+	return "mediumblob"
+}
+
 func (r *Grammar) TypeMediumInteger(_ driver.ColumnDefinition) string {
 	return "mediumint"
 }
@@ -634,6 +874,30 @@ func (r *Grammar) TypeMediumText(_ driver.ColumnDefinition) string {
 	return "mediumtext"
 }
 
+func (r *Grammar) TypeMultiLineString(column driver.ColumnDefinition) string {
+	return r.spatialType("multilinestring", column)
+}
+
+func (r *Grammar) TypeMultiPoint(column driver.ColumnDefinition) string {
+	return r.spatialType("multipoint", column)
+}
+
+func (r *Grammar) TypeMultiPolygon(column driver.ColumnDefinition) string {
+	return r.spatialType("multipolygon", column)
+}
+
+func (r *Grammar) TypePoint(column driver.ColumnDefinition) string {
+	return r.spatialType("point", column)
+}
+
+func (r *Grammar) TypePolygon(column driver.ColumnDefinition) string {
+	return r.spatialType("polygon", column)
+}
+
+func (r *Grammar) TypeSet(column driver.ColumnDefinition) string {
+	return fmt.Sprintf(`set(%s)`, strings.Join(r.wrap.Quotes(cast.ToStringSlice(columnAllowed(column))), ", "))
+}
+
 func (r *Grammar) TypeSmallInteger(_ driver.ColumnDefinition) string {
 	return "smallint"
 }
@@ -687,6 +951,10 @@ func (r *Grammar) TypeTimestampTz(column driver.ColumnDefinition) string {
 	return r.TypeTimestamp(column)
 }
 
+func (r *Grammar) TypeTinyBlob(_ driver.ColumnDefinition) string {
+	return "tinyblob"
+}
+
 func (r *Grammar) TypeTinyInteger(_ driver.ColumnDefinition) string {
 	return "tinyint"
 }
@@ -695,10 +963,28 @@ func (r *Grammar) TypeTinyText(_ driver.ColumnDefinition) string {
 	return "tinytext"
 }
 
+// TypeUuid returns char(36) by default, binary(16) if the connection stores the UUID as binary, the values should be
+// converted via CompileUuidToBin and CompileBinToUuid.
 func (r *Grammar) TypeUuid(_ driver.ColumnDefinition) string {
+	if r.uuid.Binary {
+		return "binary(16)"
+	}
+
 	return "char(36)"
 }
 
+func (r *Grammar) TypeVarBinary(column driver.ColumnDefinition) string {
+	if length := columnLength(column); length > 0 {
+		return fmt.Sprintf("varbinary(%d)", length)
+	}
+
+	return "varbinary(255)"
+}
+
+func (r *Grammar) TypeYear(_ driver.ColumnDefinition) string {
+	return "year"
+}
+
 func (r *Grammar) addModifiers(sql string, blueprint driver.Blueprint, column driver.ColumnDefinition) string {
 	for _, modifier := range r.modifiers {
 		sql += modifier(blueprint, column)
@@ -707,18 +993,153 @@ func (r *Grammar) addModifiers(sql string, blueprint driver.Blueprint, column dr
 	return sql
 }
 
-func (r *Grammar) compileKey(blueprint driver.Blueprint, command *driver.Command, ttype string) string {
-	var algorithm string
-	if command.Algorithm != "" {
-		algorithm = " using " + command.Algorithm
+// compileAlter compiles an alter table statement of the clause. When database.connections.{connection}.coalesce_alters
+// is true, the clauses of the following commands that can be combined are appended to it, and the commands are
+// skipped, so the table is altered once. The commands are combined in order, it stops at the first one that can't be
+// combined, e.g. rename table, rename column and foreign keys, or whose ALGORITHM and LOCK clauses are different.
+func (r *Grammar) compileAlter(blueprint driver.Blueprint, command *driver.Command, clause string, instant bool) string {
+	options := r.compileAlterOptions(blueprint, command, instant)
+	clauses := []string{clause}
+	if r.coalesceAlters {
+		commands := blueprint.GetCommands()
+		if index := slices.Index(commands, command); index != -1 {
+			for _, next := range commands[index+1:] {
+				if next.ShouldBeSkipped {
+					continue
+				}
+
+				nextClause, nextInstant, ok := r.alterClause(blueprint, next)
+				if !ok || r.compileAlterOptions(blueprint, next, nextInstant) != options {
+					break
+				}
+
+				clauses = append(clauses, nextClause)
+				next.ShouldBeSkipped = true
+			}
+		}
 	}
+	return fmt.Sprintf("alter table %s %s%s", r.wrap.Table(blueprint.GetTableName()), strings.Join(clauses, ", "), options)
+}
 
-	return fmt.Sprintf("alter table %s add %s %s%s(%s)",
-		r.wrap.Table(blueprint.GetTableName()),
-		ttype,
-		r.wrap.Column(command.Index),
-		algorithm,
-		r.wrap.Columnize(command.Columns))
+func (r *Grammar) checkConstraintSupported() error {
+	if !r.versionAtLeast(semver.New(8, 0, 16, "", ""), semver.New(10, 2, 1, "", "")) {
+		return CheckConstraintNotSupported.Args(r.name, r.version)
+	}
+
+	return nil
+}
+
+// alterClause returns the clause of a command in an alter table statement, whether the command can be instant,
+// and whether the command can be combined with the others.
+func (r *Grammar) alterClause(blueprint driver.Blueprint, command *driver.Command) (string, bool, bool) {
+	switch command.Name {
+	case schema.CommandAdd:
+		if command.Column.IsChange() {
+			return "modify " + r.getColumn(blueprint, command.Column), false, true
+		}
+		return "add " + r.getColumn(blueprint, command.Column), true, true
+	case schema.CommandDropColumn:
+		return r.dropColumnClause(command), false, true
+	case schema.CommandDropFullText, schema.CommandDropIndex, schema.CommandDropUnique:
+		return "drop index " + r.wrap.Column(command.Index), false, true
+	case schema.CommandDropPrimary:
+		return "drop primary key", false, true
+	case schema.CommandFullText:
+		return r.keyClause(blueprint, command, "fulltext"), false, true
+	case schema.CommandIndex:
+		return r.keyClause(blueprint, command, "index"), false, true
+	case schema.CommandPrimary:
+		return r.primaryClause(blueprint, command), false, true
+	case schema.CommandRenameIndex:
+		return r.renameIndexClause(command), false, true
+	case schema.CommandTableComment:
+		return r.tableCommentClause(command), false, true
+	case schema.CommandUnique:
+		return r.keyClause(blueprint, command, "unique"), false, true
+	}
+
+	return "", false, false
+}
+
+// compileAlterOptions compiles the ALGORITHM and LOCK clauses of an alter table statement, the ones of the column and
+// the index override the ones of the blueprint, which override the ones of the connection. In the strict mode, a
+// statement without them uses instant if it can be instant, otherwise inplace without lock, so the server fails the
+// statement rather than choosing a blocking algorithm silently. They are skipped when the foreign key is added, which
+// can't be inplace while foreign_key_checks is enabled, and when the table is created, nothing is locked then.
+func (r *Grammar) compileAlterOptions(blueprint driver.Blueprint, command *driver.Command, instant bool) string {
+	if command.Name == schema.CommandForeign {
+		return ""
+	}
+
+	algorithm, lock := r.onlineDDL.Algorithm, r.onlineDDL.Lock
+	if definition := tableDefinition(blueprint); definition != nil {
+		algorithm = cmp.Or(definition.alter.algorithm, algorithm)
+		lock = cmp.Or(definition.alter.lock, lock)
+		if index, ok := definition.indexes[command.Index]; ok && command.Index != "" {
+			algorithm = cmp.Or(index.algorithm, algorithm)
+			lock = cmp.Or(index.lock, lock)
+		}
+	}
+	if command.Column != nil {
+		if definition := columnDefinition(command.Column); definition != nil {
+			algorithm = cmp.Or(definition.GetAlgorithm(), algorithm)
+			lock = cmp.Or(definition.GetLock(), lock)
+		}
+	}
+
+	if r.onlineDDL.Strict && algorithm == "" && lock == "" {
+		// Instant doesn't accept a lock other than the default one.
+		if instant && r.versionAtLeast(semver.New(8, 0, 12, "", ""), semver.New(10, 3, 2, "", "")) {
+			algorithm = AlgorithmInstant
+		} else {
+			algorithm, lock = AlgorithmInplace, LockNone
+		}
+	}
+
+	var options []string
+	if algorithm != "" {
+		options = append(options, "algorithm = "+algorithm)
+	}
+	if lock != "" {
+		options = append(options, "lock = "+lock)
+	}
+	if len(options) == 0 || blueprint.HasCommand(schema.CommandCreate) {
+		return ""
+	}
+
+	return ", " + strings.Join(options, ", ")
+}
+
+// compileIndexParts compiles the key parts of the index, they are the columns of the command if the key parts
+// aren't set via TableDefinition.IndexParts.
+func (r *Grammar) compileIndexParts(blueprint driver.Blueprint, command *driver.Command) string {
+	definition := tableDefinition(blueprint)
+	if definition == nil || len(definition.indexParts[command.Index]) == 0 {
+		return r.wrap.Columnize(command.Columns)
+	}
+
+	var parts []string
+	for _, part := range definition.indexParts[command.Index] {
+		var sql string
+		if part.expression != "" {
+			sql = "(" + part.expression + ")"
+		} else {
+			sql = r.wrap.Column(part.column)
+		}
+		if part.length > 0 {
+			sql += fmt.Sprintf("(%d)", part.length)
+		}
+		if part.desc {
+			sql += " desc"
+		}
+		parts = append(parts, sql)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func (r *Grammar) compileKey(blueprint driver.Blueprint, command *driver.Command, ttype string) string {
+	return r.compileAlter(blueprint, command, r.keyClause(blueprint, command, ttype), false)
 }
 
 func (r *Grammar) compileLegacyRenameColumn(blueprint driver.Blueprint, command *driver.Command, columns []driver.Column) (string, error) {
@@ -728,20 +1149,104 @@ func (r *Grammar) compileLegacyRenameColumn(blueprint driver.Blueprint, command
 	if len(columns) == 0 {
 		return "", errors.New(fmt.Sprintf("Column %s does not exist", command.From))
 	}
-
-	sql := columns[0].Type
-	if len(columns[0].Collation) > 0 {
-		sql += " collate " + columns[0].Collation
+	// The generation expression isn't listed by the framework, so the generated column can't be rebuilt.
+	if _, ok := generatedColumns[columns[0].Extra]; ok {
+		return "", GeneratedColumnRenameNotSupported.Args(command.From, r.name, r.version)
 	}
 
-	return fmt.Sprintf("alter table %s change %s %s %s",
+	return fmt.Sprintf("alter table %s change %s %s %s%s",
 		r.wrap.Table(blueprint.GetTableName()),
 		r.wrap.Column(command.From),
 		r.wrap.Column(command.To),
-		r.addModifiers(sql, blueprint, r.rebuildColumnDefinition(columns[0])),
+		r.addModifiers(columns[0].Type, blueprint, r.rebuildColumnDefinition(columns[0])),
+		r.compileAlterOptions(blueprint, command, false),
 	), nil
 }
 
+func (r *Grammar) compilePartitioning(partitioning *partitioning) string {
+	if partitioning == nil {
+		return ""
+	}
+
+	expression := partitioning.expression
+	if len(partitioning.columns) > 0 || partitioning.method == PartitionMethodKey {
+		expression = r.wrap.Columnize(partitioning.columns)
+	}
+
+	sql := fmt.Sprintf(" partition by %s (%s)", partitioning.method, expression)
+	if partitioning.count > 0 {
+		sql += fmt.Sprintf(" partitions %d", partitioning.count)
+	}
+	if len(partitioning.partitions) > 0 {
+		sql += " " + r.compilePartitionDefinitions(partitioning.partitions)
+	}
+
+	return sql
+}
+
+func (r *Grammar) compilePartitionDefinitions(partitions []Partition) string {
+	definitions := make([]string, len(partitions))
+	for i, partition := range partitions {
+		definitions[i] = fmt.Sprintf("partition %s values %s", r.wrap.Column(partition.Name), partition.Values)
+	}
+
+	return "(" + strings.Join(definitions, ", ") + ")"
+}
+
+func (r *Grammar) compilePartitionNames(partitions []string) string {
+	names := make([]string, len(partitions))
+	for i, partition := range partitions {
+		names[i] = r.wrap.Column(partition)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// compileTableOptions compiles the options of the blueprint, the engine and collation fall back to the defaults of
+// the connection. The table comment is inlined, so its command is skipped.
+func (r *Grammar) compileTableOptions(definition *TableDefinition, commentCommand *driver.Command) string {
+	var options []string
+	if engine := cmp.Or(definition.engine, r.engine); engine != "" {
+		options = append(options, "engine = "+engine)
+	}
+	if definition.charset != "" {
+		options = append(options, "default character set "+definition.charset)
+	}
+	// The collation of the connection may not belong to the charset of the table.
+	collation := definition.collation
+	if definition.charset == "" && collation == "" {
+		collation = r.collation
+	}
+	if collation != "" {
+		options = append(options, "collate "+collation)
+	}
+	if definition.rowFormat != "" {
+		options = append(options, "row_format = "+definition.rowFormat)
+	}
+	if definition.autoIncrement != nil {
+		options = append(options, fmt.Sprintf("auto_increment = %d", *definition.autoIncrement))
+	}
+	if definition.keyBlockSize > 0 {
+		options = append(options, fmt.Sprintf("key_block_size = %d", definition.keyBlockSize))
+	}
+	if definition.statsPersistent != nil {
+		options = append(options, fmt.Sprintf("stats_persistent = %d", cast.ToInt(*definition.statsPersistent)))
+	}
+	if commentCommand != nil {
+		options = append(options, fmt.Sprintf("comment = '%s'", strings.ReplaceAll(commentCommand.Value, "'", "''")))
+		commentCommand.ShouldBeSkipped = true
+	}
+	if len(options) == 0 {
+		return ""
+	}
+
+	return " " + strings.Join(options, " ")
+}
+
+func (r *Grammar) dropColumnClause(command *driver.Command) string {
+	return strings.Join(r.wrap.PrefixArray("drop", r.wrap.Columns(command.Columns)), ", ")
+}
+
 func (r *Grammar) getColumns(blueprint driver.Blueprint) []string {
 	var columns []string
 	for _, column := range blueprint.GetAddedColumns() {
@@ -752,11 +1257,45 @@ func (r *Grammar) getColumns(blueprint driver.Blueprint) []string {
 }
 
 func (r *Grammar) getColumn(blueprint driver.Blueprint, column driver.ColumnDefinition) string {
-	sql := fmt.Sprintf("%s %s", r.wrap.Column(column.GetName()), schema.ColumnType(r, column))
+	sql := fmt.Sprintf("%s %s", r.wrap.Column(column.GetName()), schema.ColumnType(r.dialect, column))
 
 	return r.addModifiers(sql, blueprint, column)
 }
 
+func (r *Grammar) keyClause(blueprint driver.Blueprint, command *driver.Command, ttype string) string {
+	var algorithm string
+	if command.Algorithm != "" {
+		algorithm = " using " + command.Algorithm
+	}
+
+	if ttype == "index" {
+		if definition := tableDefinition(blueprint); definition != nil && definition.spatialIndexes[command.Index] {
+			ttype = "spatial index"
+		}
+	}
+
+	return fmt.Sprintf("add %s %s%s(%s)", ttype, r.wrap.Column(command.Index), algorithm, r.compileIndexParts(blueprint, command))
+}
+
+// legacyRenameColumn returns whether the server can't rename a column, MySQL supports RENAME COLUMN since 8.0.3 and
+// MariaDB since 10.5.2, before that the column is rebuilt with CHANGE.
+func (r *Grammar) legacyRenameColumn() bool {
+	if _, err := semver.NewVersion(r.version); err != nil {
+		return false
+	}
+
+	return !r.versionAtLeast(semver.New(8, 0, 3, "", ""), semver.New(10, 5, 2, "", ""))
+}
+
+func (r *Grammar) primaryClause(blueprint driver.Blueprint, command *driver.Command) string {
+	var algorithm string
+	if command.Algorithm != "" {
+		algorithm = "using " + command.Algorithm
+	}
+
+	return fmt.Sprintf("add primary key %s(%s)", algorithm, r.compileIndexParts(blueprint, command))
+}
+
 func (r *Grammar) rebuildColumnDefinition(column driver.Column) driver.ColumnDefinition {
 	definition := schema.NewColumnDefinition(column.Name, column.Type)
 	if column.Autoincrement {
@@ -765,6 +1304,7 @@ func (r *Grammar) rebuildColumnDefinition(column driver.Column) driver.ColumnDef
 	if len(column.Comment) > 0 {
 		definition.Comment(column.Comment)
 	}
+	rebuilt := &ColumnDefinition{attributes: &columnAttributes{collation: column.Collation}}
 	if len(column.Default) > 0 {
 		definition.Default(schema.Expression(column.Default))
 	}
@@ -775,8 +1315,48 @@ func (r *Grammar) rebuildColumnDefinition(column driver.Column) driver.ColumnDef
 		onUpdate := strings.TrimPrefix(strings.TrimPrefix(column.Extra, "on update"), "ON UPDATE")
 		definition.OnUpdate(schema.Expression(onUpdate))
 	}
+	rebuilt.ColumnDefinition = definition.Change()
+
+	return rebuilt
+}
+
+// renameIndexClause returns the RENAME INDEX clause of an alter table statement.
+func (r *Grammar) renameIndexClause(command *driver.Command) string {
+	return fmt.Sprintf("rename index %s to %s", r.wrap.Column(command.From), r.wrap.Column(command.To))
+}
+
+func (r *Grammar) tableCommentClause(command *driver.Command) string {
+	return fmt.Sprintf("comment = '%s'", strings.ReplaceAll(command.Value, "'", "''"))
+}
+
+func (r *Grammar) requiredVersion(mysql, _ *semver.Version) *semver.Version {
+	return mysql
+}
+
+func (r *Grammar) spatialSridKeyword() string {
+	return "srid"
+}
+
+// spatialType appends the SRID of the column to the spatial type.
+func (r *Grammar) spatialType(ttype string, column driver.ColumnDefinition) string {
+	definition := columnDefinition(column)
+	if definition == nil || definition.GetSrid() == 0 {
+		return ttype
+	}
+
+	return fmt.Sprintf("%s %s %d", ttype, r.dialect.spatialSridKeyword(), definition.GetSrid())
+}
+
+// versionAtLeast returns whether the server is at least the version of MySQL or MariaDB that a feature requires, a nil
+// version means the server doesn't support the feature.
+func (r *Grammar) versionAtLeast(mysql, mariadb *semver.Version) bool {
+	version, err := semver.NewVersion(r.version)
+	if err != nil {
+		return false
+	}
+	required := r.dialect.requiredVersion(mysql, mariadb)
 
-	return definition.Change()
+	return required != nil && !version.LessThan(required)
 }
 
 func getCommandByName(commands []*driver.Command, name string) *driver.Command {
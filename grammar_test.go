@@ -12,6 +12,8 @@ import (
 	"github.com/goravel/framework/support/convert"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+
+	"github.com/goravel/mysql/contracts"
 )
 
 type GrammarSuite struct {
@@ -73,6 +75,130 @@ func (s *GrammarSuite) TestCompileChange() {
 	s.Equal([]string{"alter table `goravel_users` modify `name` varchar(1) not null default 'goravel' comment 'comment' first"}, sql)
 }
 
+func (s *GrammarSuite) TestCompileAlterOptions() {
+	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+	mockColumn := mocksdriver.NewColumnDefinition(s.T())
+	command := &contractsdriver.Command{Column: mockColumn}
+	indexCommand := &contractsdriver.Command{Index: "users_email_index"}
+	mockBlueprint.EXPECT().HasCommand("create").Return(false).Times(4)
+
+	s.Empty(s.grammar.compileAlterOptions(mockBlueprint, command, true))
+
+	s.grammar.onlineDDL = contracts.OnlineDDL{Algorithm: AlgorithmInplace}
+	s.Equal(", algorithm = inplace", s.grammar.compileAlterOptions(mockBlueprint, command, true))
+
+	Table(mockBlueprint).Lock(LockShared)
+	s.Equal(", algorithm = inplace, lock = shared", s.grammar.compileAlterOptions(mockBlueprint, command, true))
+
+	Table(mockBlueprint).Index("users_email_index").Algorithm(AlgorithmCopy)
+	s.Equal(", algorithm = copy, lock = shared", s.grammar.compileAlterOptions(mockBlueprint, indexCommand, false))
+
+	Column(mockColumn).Algorithm(AlgorithmInstant).Lock(LockNone)
+	s.Equal(", algorithm = instant, lock = none", s.grammar.compileAlterOptions(mockBlueprint, command, true))
+}
+
+func (s *GrammarSuite) TestCompileAlterOptionsStrict() {
+	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+	command := &contractsdriver.Command{}
+	mockBlueprint.EXPECT().HasCommand("create").Return(false).Times(6)
+	s.grammar.onlineDDL = contracts.OnlineDDL{Strict: true}
+
+	s.grammar.version = "8.0.36"
+	s.Equal(", algorithm = instant", s.grammar.compileAlterOptions(mockBlueprint, command, true))
+	s.Equal(", algorithm = inplace, lock = none", s.grammar.compileAlterOptions(mockBlueprint, command, false))
+
+	s.grammar.version = "5.7.44"
+	s.Equal(", algorithm = inplace, lock = none", s.grammar.compileAlterOptions(mockBlueprint, command, true))
+
+	s.grammar = NewMariadbGrammar("goravel", "goravel_", "10.11.6").Grammar
+	s.grammar.onlineDDL = contracts.OnlineDDL{Strict: true}
+	s.Equal(", algorithm = instant", s.grammar.compileAlterOptions(mockBlueprint, command, true))
+
+	s.grammar.version = "10.3.1"
+	s.Equal(", algorithm = inplace, lock = none", s.grammar.compileAlterOptions(mockBlueprint, command, true))
+
+	Table(mockBlueprint).Algorithm(AlgorithmCopy)
+	s.Equal(", algorithm = copy", s.grammar.compileAlterOptions(mockBlueprint, command, true))
+}
+
+func (s *GrammarSuite) TestCompileAlterOptionsSkipped() {
+	s.grammar.onlineDDL = contracts.OnlineDDL{Strict: true}
+	s.grammar.version = "8.0.36"
+
+	// The foreign key can't be added inplace while foreign_key_checks is enabled.
+	blueprint := schema.NewBlueprint(nil, "goravel_", "users")
+	blueprint.Foreign("role_id").References("id").On("roles")
+	blueprint.Index("role_id")
+
+	statements, err := blueprint.ToSql(s.grammar)
+	s.NoError(err)
+	s.Equal([]string{
+		"alter table `goravel_users` add constraint `goravel_users_role_id_foreign` foreign key (`role_id`) references `goravel_roles` (`id`)",
+		"alter table `goravel_users` add index `goravel_users_role_id_index`(`role_id`), algorithm = inplace, lock = none",
+	}, statements)
+
+	// Nothing is locked when the table is created.
+	blueprint = schema.NewBlueprint(nil, "goravel_", "users")
+	blueprint.Create()
+	blueprint.Integer("role_id")
+	blueprint.Foreign("role_id").References("id").On("roles")
+	blueprint.Index("role_id")
+	Table(blueprint).Algorithm(AlgorithmCopy)
+
+	statements, err = blueprint.ToSql(s.grammar)
+	s.NoError(err)
+	s.Equal([]string{
+		"create table `goravel_users` (`role_id` int not null)",
+		"alter table `goravel_users` add constraint `goravel_users_role_id_foreign` foreign key (`role_id`) references `goravel_roles` (`id`)",
+		"alter table `goravel_users` add index `goravel_users_role_id_index`(`role_id`)",
+	}, statements)
+}
+
+func (s *GrammarSuite) TestCompileAlterCoalesced() {
+	blueprint := schema.NewBlueprint(nil, "goravel_", "users")
+	blueprint.String("name")
+	blueprint.Integer("age").After("name")
+	blueprint.Index("name")
+	blueprint.Foreign("age").References("id").On("ages")
+	blueprint.Unique("age")
+	blueprint.Comment("users")
+
+	statements, err := blueprint.ToSql(s.grammar)
+	s.NoError(err)
+	s.Len(statements, 6)
+
+	s.grammar.coalesceAlters = true
+	blueprint = schema.NewBlueprint(nil, "goravel_", "users")
+	blueprint.String("name")
+	blueprint.Integer("age").After("name")
+	blueprint.Index("name")
+	blueprint.Foreign("age").References("id").On("ages")
+	blueprint.Unique("age")
+	blueprint.Comment("users")
+
+	statements, err = blueprint.ToSql(s.grammar)
+	s.NoError(err)
+	s.Equal([]string{
+		"alter table `goravel_users` add `name` varchar(255) not null, add `age` int not null after `name`, add index `goravel_users_name_index`(`name`)",
+		"alter table `goravel_users` add constraint `goravel_users_age_foreign` foreign key (`age`) references `goravel_ages` (`id`)",
+		"alter table `goravel_users` add unique `goravel_users_age_unique`(`age`), comment = 'users'",
+	}, statements)
+
+	s.grammar.onlineDDL = contracts.OnlineDDL{Strict: true}
+	s.grammar.version = "8.0.36"
+	blueprint = schema.NewBlueprint(nil, "goravel_", "users")
+	blueprint.String("name")
+	blueprint.Integer("age")
+	blueprint.Index("name")
+
+	statements, err = blueprint.ToSql(s.grammar)
+	s.NoError(err)
+	s.Equal([]string{
+		"alter table `goravel_users` add `name` varchar(255) not null, add `age` int not null, algorithm = instant",
+		"alter table `goravel_users` add index `goravel_users_name_index`(`name`), algorithm = inplace, lock = none",
+	}, statements)
+}
+
 func (s *GrammarSuite) TestCompileCreate() {
 	mockColumn1 := mocksdriver.NewColumnDefinition(s.T())
 	mockColumn2 := mocksdriver.NewColumnDefinition(s.T())
@@ -134,6 +260,198 @@ func (s *GrammarSuite) TestCompileCreate() {
 	s.True(primaryCommand.ShouldBeSkipped)
 }
 
+func (s *GrammarSuite) TestCompileCreateWithTableOptions() {
+	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+	commentCommand := &contractsdriver.Command{
+		Name:  schema.CommandTableComment,
+		Value: "It's a table comment",
+	}
+
+	mockBlueprint.EXPECT().GetAddedColumns().Return(nil).Twice()
+	mockBlueprint.EXPECT().GetCommands().Return(nil).Once()
+	mockBlueprint.EXPECT().GetTableName().Return("users").Twice()
+
+	s.grammar.engine = "InnoDB"
+	s.grammar.collation = "utf8mb4_0900_ai_ci"
+	s.Equal("create table `goravel_users` () engine = InnoDB collate utf8mb4_0900_ai_ci", s.grammar.CompileCreate(mockBlueprint))
+
+	mockBlueprint.EXPECT().GetCommands().Return([]*contractsdriver.Command{commentCommand}).Once()
+	Table(mockBlueprint).
+		Engine("MyISAM").
+		Charset("utf8mb4").
+		Collation("utf8mb4_bin").
+		RowFormat("COMPRESSED").
+		AutoIncrement(1000).
+		KeyBlockSize(8).
+		StatsPersistent(false)
+
+	s.Equal("create table `goravel_users` () engine = MyISAM default character set utf8mb4 collate utf8mb4_bin "+
+		"row_format = COMPRESSED auto_increment = 1000 key_block_size = 8 stats_persistent = 0 comment = 'It''s a table comment'",
+		s.grammar.CompileCreate(mockBlueprint))
+	s.True(commentCommand.ShouldBeSkipped)
+}
+
+func (s *GrammarSuite) TestCompileCreateWithTableCharset() {
+	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+	mockBlueprint.EXPECT().GetAddedColumns().Return(nil).Once()
+	mockBlueprint.EXPECT().GetCommands().Return(nil).Once()
+	mockBlueprint.EXPECT().GetTableName().Return("users").Once()
+
+	s.grammar.collation = "utf8mb4_unicode_ci"
+	Table(mockBlueprint).Charset("latin1")
+
+	s.Equal("create table `goravel_users` () default character set latin1", s.grammar.CompileCreate(mockBlueprint))
+}
+
+func (s *GrammarSuite) TestCompileCreateWithPartitioning() {
+	tests := []struct {
+		name     string
+		table    func(definition *TableDefinition)
+		expected string
+	}{
+		{
+			name: "range",
+			table: func(definition *TableDefinition) {
+				definition.PartitionByRange("year(`created_at`)", PartitionLessThan("p2025", "2026"), PartitionLessThanMaxValue("pmax"))
+			},
+			expected: " partition by range (year(`created_at`)) (partition `p2025` values less than (2026), partition `pmax` values less than maxvalue)",
+		},
+		{
+			name: "range columns",
+			table: func(definition *TableDefinition) {
+				definition.PartitionByRangeColumns([]string{"created_at"}, PartitionLessThan("p202501", "'2025-02-01'"))
+			},
+			expected: " partition by range columns (`created_at`) (partition `p202501` values less than ('2025-02-01'))",
+		},
+		{
+			name: "list",
+			table: func(definition *TableDefinition) {
+				definition.PartitionByList("`region_id`", PartitionIn("p_east", "1", "2"), PartitionIn("p_west", "3"))
+			},
+			expected: " partition by list (`region_id`) (partition `p_east` values in (1, 2), partition `p_west` values in (3))",
+		},
+		{
+			name: "hash",
+			table: func(definition *TableDefinition) {
+				definition.PartitionByHash("`id`", 4)
+			},
+			expected: " partition by hash (`id`) partitions 4",
+		},
+		{
+			name: "key",
+			table: func(definition *TableDefinition) {
+				definition.PartitionByKey(nil, 8)
+			},
+			expected: " partition by key () partitions 8",
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			mockBlueprint := mocksdriver.NewBlueprint(s.T())
+			mockBlueprint.EXPECT().GetAddedColumns().Return(nil).Once()
+			mockBlueprint.EXPECT().GetCommands().Return(nil).Once()
+			mockBlueprint.EXPECT().GetTableName().Return("events").Once()
+
+			test.table(Table(mockBlueprint))
+
+			s.Equal("create table `goravel_events` ()"+test.expected, s.grammar.CompileCreate(mockBlueprint))
+		})
+	}
+}
+
+func (s *GrammarSuite) TestCompileIndexWithAlterOptions() {
+	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+	mockBlueprint.EXPECT().GetTableName().Return("users").Once()
+	mockBlueprint.EXPECT().HasCommand("create").Return(false).Once()
+	Table(mockBlueprint).Algorithm(AlgorithmInplace).Lock(LockNone)
+
+	s.Equal("alter table `goravel_users` add index `users_email_index`(`email`), algorithm = inplace, lock = none",
+		s.grammar.CompileIndex(mockBlueprint, &contractsdriver.Command{
+			Index:   "users_email_index",
+			Columns: []string{"email"},
+		}))
+}
+
+func (s *GrammarSuite) TestCompileIndexWithIndexParts() {
+	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+	mockBlueprint.EXPECT().GetTableName().Return("users").Times(3)
+	Table(mockBlueprint).
+		IndexParts("users_email_lower_index", IndexExpression("lower(`email`)")).
+		IndexParts("users_name_created_at_index", IndexColumn("name").Length(50), IndexColumn("created_at").Desc()).
+		IndexParts("users_pkey", IndexColumn("id").Desc())
+
+	s.Equal("alter table `goravel_users` add index `users_email_lower_index`((lower(`email`)))",
+		s.grammar.CompileIndex(mockBlueprint, &contractsdriver.Command{
+			Index:   "users_email_lower_index",
+			Columns: []string{"email"},
+		}))
+	s.Equal("alter table `goravel_users` add unique `users_name_created_at_index`(`name`(50), `created_at` desc)",
+		s.grammar.CompileUnique(mockBlueprint, &contractsdriver.Command{
+			Index:   "users_name_created_at_index",
+			Columns: []string{"name", "created_at"},
+		}))
+	s.Equal("alter table `goravel_users` add primary key (`id` desc)",
+		s.grammar.CompilePrimary(mockBlueprint, &contractsdriver.Command{
+			Index:   "users_pkey",
+			Columns: []string{"id"},
+		}))
+}
+
+func (s *GrammarSuite) TestCompileIndexes() {
+	sql, err := s.grammar.CompileIndexes("", "users")
+	s.NoError(err)
+	s.Equal("select index_name as `name`, group_concat(column_name order by seq_in_index) as `columns`, "+
+		"index_type as `type`, not non_unique as `unique` "+
+		"from information_schema.statistics where table_schema = 'goravel' and table_name = 'goravel_users' "+
+		"group by index_name, index_type, non_unique", sql)
+}
+
+func (s *GrammarSuite) TestCompileIndexParts() {
+	s.Equal("select index_name as `index`, column_name as `column`, null as `expression`, "+
+		"sub_part as `length`, collation = 'D' as `desc`, seq_in_index as `position` "+
+		"from information_schema.statistics where table_schema = 'goravel' and table_name = 'goravel_users' "+
+		"order by index_name, seq_in_index", s.grammar.CompileIndexParts("users"))
+
+	s.Contains(NewGrammar("goravel", "goravel_", "8.0.13", Name).CompileIndexParts("users"), "expression as `expression`")
+	s.Contains(NewMariadbGrammar("goravel", "goravel_", "11.4.2").CompileIndexParts("users"), "null as `expression`")
+}
+
+func (s *GrammarSuite) TestCompileSpatialIndex() {
+	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+	mockBlueprint.EXPECT().GetTableName().Return("places").Times(3)
+
+	s.Equal("alter table `goravel_places` add spatial index `places_location_spatial`(`location`)",
+		s.grammar.CompileSpatialIndex(mockBlueprint, &contractsdriver.Command{
+			Index:   "places_location_spatial",
+			Columns: []string{"location"},
+		}))
+	s.Equal("alter table `goravel_places` add index `places_location_index`(`location`)",
+		s.grammar.CompileIndex(mockBlueprint, &contractsdriver.Command{
+			Index:   "places_location_index",
+			Columns: []string{"location"},
+		}))
+
+	Table(mockBlueprint).SpatialIndex("places_location_index")
+
+	s.Equal("alter table `goravel_places` add spatial index `places_location_index`(`location`)",
+		s.grammar.CompileIndex(mockBlueprint, &contractsdriver.Command{
+			Index:   "places_location_index",
+			Columns: []string{"location"},
+		}))
+}
+
+func (s *GrammarSuite) TestCompileSpatialQueries() {
+	sql, args := s.grammar.CompileDistanceSphere("location", GeometryFromText("POINT(39.9 116.4)", 4326))
+	s.Equal("st_distance_sphere(`location`, st_geomfromtext(?, ?))", sql)
+	s.Equal([]any{"POINT(39.9 116.4)", 4326}, args)
+
+	wkb := []byte{0x01, 0x01, 0x00, 0x00, 0x00}
+	sql, args = s.grammar.CompileContains("regions.area", GeometryFromWKB(wkb, 0))
+	s.Equal("st_contains(`goravel_regions`.`area`, st_geomfromwkb(?))", sql)
+	s.Equal([]any{wkb}, args)
+}
+
 func (s *GrammarSuite) TestCompileDropAllTables() {
 	s.Equal([]string{
 		"SET FOREIGN_KEY_CHECKS=0;",
@@ -172,6 +490,61 @@ func (s *GrammarSuite) TestCompileDropIfExists() {
 	s.Equal("drop table if exists `goravel_users`", s.grammar.CompileDropIfExists(mockBlueprint))
 }
 
+func (s *GrammarSuite) TestCompileCheckConstraints() {
+	grammar := NewGrammar("goravel", "goravel_", "8.0.16", Name)
+
+	sql, err := grammar.CompileCheck("users", "users_age_check", "`age` >= 18")
+	s.NoError(err)
+	s.Equal("alter table `goravel_users` add constraint `users_age_check` check (`age` >= 18)", sql)
+
+	sql, err = grammar.CompileDropCheck("users", "users_age_check")
+	s.NoError(err)
+	s.Equal("alter table `goravel_users` drop check `users_age_check`", sql)
+
+	sql, err = grammar.CompileCheckConstraints("users")
+	s.NoError(err)
+	s.Equal("select cc.constraint_name as `name`, cc.check_clause as `expression` "+
+		"from information_schema.check_constraints as cc "+
+		"join information_schema.table_constraints as tc "+
+		"on tc.constraint_schema = cc.constraint_schema and tc.constraint_name = cc.constraint_name and tc.constraint_type = 'CHECK' "+
+		"where tc.table_schema = 'goravel' and tc.table_name = 'goravel_users' "+
+		"order by cc.constraint_name", sql)
+
+	sql, err = s.grammar.CompileCheck("users", "users_age_check", "`age` >= 18")
+	s.Equal(CheckConstraintNotSupported.Args(Name, "8.0.3"), err)
+	s.Empty(sql)
+
+	_, err = NewMariadbGrammar("goravel", "goravel_", "10.1.48").CompileCheck("users", "users_age_check", "`age` >= 18")
+	s.Equal(CheckConstraintNotSupported.Args("MariaDB", "10.1.48"), err)
+
+	_, err = NewGrammar("goravel", "goravel_", "", Name).CompileCheckConstraints("users")
+	s.Equal(CheckConstraintsNotListed.Args(Name, ""), err)
+
+	// MariaDB enforces the constraint since 10.2.1, but lists it since 10.2.22
+	grammar = NewMariadbGrammar("goravel", "goravel_", "10.2.10").Grammar
+	_, err = grammar.CompileCheck("users", "users_age_check", "`age` >= 18")
+	s.NoError(err)
+	_, err = grammar.CompileCheckConstraints("users")
+	s.Equal(CheckConstraintsNotListed.Args("MariaDB", "10.2.10"), err)
+}
+
+func (s *GrammarSuite) TestCompilePartitionCommands() {
+	s.Equal("alter table `goravel_events` add partition (partition `p2026` values less than (2027))",
+		s.grammar.CompileAddPartitions("events", PartitionLessThan("p2026", "2027")))
+	s.Equal("alter table `goravel_events` drop partition `p2024`, `p2025`",
+		s.grammar.CompileDropPartitions("events", "p2024", "p2025"))
+	s.Equal("alter table `goravel_events` exchange partition `p2024` with table `goravel_events_2024`",
+		s.grammar.CompileExchangePartition("events", "p2024", "events_2024"))
+	s.Equal("alter table `goravel_events` reorganize partition `pmax` into (partition `p2026` values less than (2027), partition `pmax` values less than maxvalue)",
+		s.grammar.CompileReorganizePartitions("events", []string{"pmax"}, PartitionLessThan("p2026", "2027"), PartitionLessThanMaxValue("pmax")))
+	s.Equal("alter table `goravel_events` truncate partition `p2024`",
+		s.grammar.CompileTruncatePartitions("events", "p2024"))
+	s.Equal("select partition_name as `name`, partition_method as `method`, partition_expression as `expression`, "+
+		"partition_description as `description`, partition_ordinal_position as `position`, table_rows as `rows` "+
+		"from information_schema.partitions where table_schema = 'goravel' and table_name = 'goravel_events' and partition_name is not null "+
+		"order by partition_ordinal_position", s.grammar.CompilePartitions("events"))
+}
+
 func (s *GrammarSuite) TestCompileForeign() {
 	var mockBlueprint *mocksdriver.Blueprint
 
@@ -551,6 +924,19 @@ func (s *GrammarSuite) TestCompileKey() {
 	}, "unique"))
 }
 
+func (s *GrammarSuite) TestCompileColumns() {
+	sql, err := s.grammar.CompileColumns("", "users")
+	s.NoError(err)
+	s.Contains(sql, "column_default as `default`")
+	s.NotContains(sql, "coalesce")
+
+	// The default is the real default on the servers that rebuild the column to rename it
+	sql, err = NewGrammar("goravel", "goravel_", "5.7.44", Name).CompileColumns("", "users")
+	s.NoError(err)
+	s.Contains(sql, "column_default as `default`")
+	s.NotContains(sql, "coalesce")
+}
+
 func (s *GrammarSuite) TestCompileRenameColumn() {
 	var (
 		mockBlueprint = mocksdriver.NewBlueprint(s.T())
@@ -589,6 +975,22 @@ func (s *GrammarSuite) TestCompileRenameColumn() {
 
 	s.NoError(err)
 	s.Equal("alter table `goravel_users` change `before` `after` varchar collate utf8mb4_unicode_ci null default 'goravel' comment 'test comment'", sql)
+
+	// Test case: the generated column can't be rebuilt when MySQL version is less than 8.0.3
+	sql, err = s.grammar.CompileRenameColumn(mockBlueprint, &contractsdriver.Command{
+		Column: mockColumn,
+		From:   "before",
+		To:     "after",
+	}, []contractsdriver.Column{
+		{
+			Extra: "stored generated",
+			Name:  "before",
+			Type:  "int",
+		},
+	})
+
+	s.Equal(GeneratedColumnRenameNotSupported.Args("before", Name, "5.7.2"), err)
+	s.Empty(sql)
 }
 
 func (s *GrammarSuite) TestGetColumns() {
@@ -626,6 +1028,77 @@ func (s *GrammarSuite) TestGetColumns() {
 	s.Equal([]string{"`id` int unsigned not null auto_increment primary key comment 'id'", "`name` varchar(10) null default 'goravel' comment 'name'"}, s.grammar.getColumns(mockBlueprint))
 }
 
+func (s *GrammarSuite) TestModifyCharsetAndCollation() {
+	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+	mockColumn := mocksdriver.NewColumnDefinition(s.T())
+
+	s.Empty(s.grammar.ModifyCharset(mockBlueprint, mockColumn))
+	s.Empty(s.grammar.ModifyCollation(mockBlueprint, mockColumn))
+
+	Column(mockColumn).Charset("utf8mb4").Collation("utf8mb4_bin")
+
+	s.Equal(" character set utf8mb4", s.grammar.ModifyCharset(mockBlueprint, mockColumn))
+	s.Equal(" collate utf8mb4_bin", s.grammar.ModifyCollation(mockBlueprint, mockColumn))
+
+	column := schema.NewColumnDefinition("token", "string")
+	Column(column).Collation("utf8mb4_bin")
+
+	s.Equal("`token` varchar(255) collate utf8mb4_bin not null", s.grammar.getColumn(mockBlueprint, column))
+}
+
+func (s *GrammarSuite) TestTableOptionsAreKept() {
+	blueprint := schema.NewBlueprint(nil, "goravel_", "users")
+	blueprint.Create()
+	blueprint.String("name")
+	blueprint.Index("name")
+	blueprint.Rename("people")
+	Table(blueprint).Engine("InnoDB").Charset("utf8mb4")
+
+	for range 2 {
+		statements, err := blueprint.ToSql(s.grammar)
+		s.NoError(err)
+		s.Equal([]string{
+			"create table `goravel_users` (`name` varchar(255) not null) engine = InnoDB default character set utf8mb4",
+			"alter table `goravel_users` add index `goravel_users_name_index`(`name`)",
+			"rename table `goravel_users` to `goravel_people`",
+		}, statements)
+	}
+
+	// The options of a blueprint without the commands that read them are kept as they are.
+	blueprint = schema.NewBlueprint(nil, "goravel_", "users")
+	blueprint.Rename("people")
+	Table(blueprint).Engine("InnoDB")
+
+	statements, err := blueprint.ToSql(s.grammar)
+	s.NoError(err)
+	s.Equal([]string{"rename table `goravel_users` to `goravel_people`"}, statements)
+	s.Equal("InnoDB", tableDefinition(blueprint).engine)
+}
+
+func (s *GrammarSuite) TestColumnAttributesAreKept() {
+	blueprint := schema.NewBlueprint(nil, "goravel_", "users")
+	blueprint.Create()
+	created := blueprint.String("token")
+	Column(created).Collation("utf8mb4_bin")
+
+	for range 2 {
+		statements, err := blueprint.ToSql(s.grammar)
+		s.NoError(err)
+		s.Equal([]string{"create table `goravel_users` (`token` varchar(255) collate utf8mb4_bin not null)"}, statements)
+	}
+
+	blueprint = schema.NewBlueprint(nil, "goravel_", "users")
+	added := blueprint.String("token")
+	Column(added).Charset("utf8mb4").Collation("utf8mb4_bin")
+
+	for range 2 {
+		statements, err := blueprint.ToSql(s.grammar)
+		s.NoError(err)
+		s.Equal([]string{"alter table `goravel_users` add `token` varchar(255) character set utf8mb4 collate utf8mb4_bin not null"}, statements)
+	}
+	s.NotNil(columnDefinition(added))
+}
+
 func (s *GrammarSuite) TestModifyDefault() {
 	var (
 		mockBlueprint *mocksdriver.Blueprint
@@ -706,6 +1179,26 @@ func (s *GrammarSuite) TestModifyOnUpdate() {
 	s.Empty(s.grammar.ModifyOnUpdate(mockBlueprint, mockColumn))
 }
 
+func (s *GrammarSuite) TestModifyStoredAs() {
+	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+
+	s.Equal(" as (`price` * `quantity`) stored", s.grammar.ModifyStoredAs(mockBlueprint,
+		Column(schema.NewColumnDefinition("total", "integer")).StoredAs("`price` * `quantity`")))
+	s.Empty(s.grammar.ModifyStoredAs(mockBlueprint,
+		Column(schema.NewColumnDefinition("total", "integer")).VirtualAs("`price` * `quantity`")))
+	s.Empty(s.grammar.ModifyStoredAs(mockBlueprint, mocksdriver.NewColumnDefinition(s.T())))
+}
+
+func (s *GrammarSuite) TestModifyVirtualAs() {
+	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+
+	s.Equal(" as (`price` * `quantity`)", s.grammar.ModifyVirtualAs(mockBlueprint,
+		Column(schema.NewColumnDefinition("total", "integer")).VirtualAs("`price` * `quantity`")))
+	s.Empty(s.grammar.ModifyVirtualAs(mockBlueprint,
+		Column(schema.NewColumnDefinition("total", "integer")).StoredAs("`price` * `quantity`")))
+	s.Empty(s.grammar.ModifyVirtualAs(mockBlueprint, mocksdriver.NewColumnDefinition(s.T())))
+}
+
 func (s *GrammarSuite) TestTableComment() {
 	mockBlueprint := mocksdriver.NewBlueprint(s.T())
 	mockBlueprint.EXPECT().GetTableName().Return("users").Once()
@@ -715,6 +1208,37 @@ func (s *GrammarSuite) TestTableComment() {
 	}))
 }
 
+func (s *GrammarSuite) TestTypeBinary() {
+	mockColumn := mocksdriver.NewColumnDefinition(s.T())
+	mockColumn.EXPECT().GetLength().Return(0).Twice()
+
+	s.Equal("binary(255)", s.grammar.TypeBinary(mockColumn))
+	s.Equal("varbinary(255)", s.grammar.TypeVarBinary(mockColumn))
+
+	column := schema.NewColumnDefinition("token", "binary")
+	Column(column).Length(16)
+
+	s.Equal("binary(16)", s.grammar.TypeBinary(column))
+	s.Equal("varbinary(16)", s.grammar.TypeVarBinary(column))
+	s.Equal("bit(16)", s.grammar.TypeBit(column))
+	s.Equal("blob", s.grammar.TypeBlob(column))
+	s.Equal("tinyblob", s.grammar.TypeTinyBlob(column))
+	s.Equal("mediumblob", s.grammar.TypeMediumBlob(column))
+	s.Equal("longblob", s.grammar.TypeLongBlob(column))
+	s.Equal("year", s.grammar.TypeYear(column))
+}
+
+func (s *GrammarSuite) TestTypeBit() {
+	mockColumn := mocksdriver.NewColumnDefinition(s.T())
+	mockColumn.EXPECT().GetLength().Return(0).Once()
+
+	s.Equal("bit", s.grammar.TypeBit(mockColumn))
+
+	mockColumn.EXPECT().GetLength().Return(8).Once()
+
+	s.Equal("bit(8)", s.grammar.TypeBit(mockColumn))
+}
+
 func (s *GrammarSuite) TestTypeBoolean() {
 	mockColumn := mocksdriver.NewColumnDefinition(s.T())
 
@@ -763,6 +1287,99 @@ func (s *GrammarSuite) TestTypeFloat() {
 	s.Equal("float(2)", s.grammar.TypeFloat(mockColumn))
 }
 
+func (s *GrammarSuite) TestTypeSet() {
+	mockColumn := mocksdriver.NewColumnDefinition(s.T())
+	mockColumn.EXPECT().GetAllowed().Return([]any{"read", "write"}).Once()
+
+	s.Equal(`set('read', 'write')`, s.grammar.TypeSet(mockColumn))
+
+	column := schema.NewColumnDefinition("permissions", "set")
+	Column(column).Allowed("read", "write", "delete")
+
+	s.Equal(`set('read', 'write', 'delete')`, s.grammar.TypeSet(column))
+	s.Equal("`permissions` set('read', 'write', 'delete') not null", s.grammar.getColumn(mocksdriver.NewBlueprint(s.T()), column))
+}
+
+func (s *GrammarSuite) TestTypeSpatial() {
+	mockColumn := mocksdriver.NewColumnDefinition(s.T())
+
+	s.Equal("geometry", s.grammar.TypeGeometry(mockColumn))
+	s.Equal("geometrycollection", s.grammar.TypeGeometryCollection(mockColumn))
+	s.Equal("linestring", s.grammar.TypeLineString(mockColumn))
+	s.Equal("multilinestring", s.grammar.TypeMultiLineString(mockColumn))
+	s.Equal("multipoint", s.grammar.TypeMultiPoint(mockColumn))
+	s.Equal("multipolygon", s.grammar.TypeMultiPolygon(mockColumn))
+	s.Equal("polygon", s.grammar.TypePolygon(mockColumn))
+	s.Equal("point", s.grammar.TypePoint(mockColumn))
+
+	Column(mockColumn).Srid(4326)
+
+	s.Equal("point srid 4326", s.grammar.TypePoint(mockColumn))
+
+	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+	column := schema.NewColumnDefinition("location", "point")
+	Column(column).Srid(4326)
+
+	s.Equal("`location` point srid 4326 not null", s.grammar.getColumn(mockBlueprint, column))
+}
+
+func (s *GrammarSuite) TestCompileUuidConversions() {
+	uuid := "6ccd780c-baba-1026-9564-5b8c656024db"
+	tests := []struct {
+		name         string
+		grammar      *Grammar
+		uuid         contracts.Uuid
+		expectToBin  string
+		expectToUuid string
+	}{
+		{
+			name:         "char",
+			grammar:      NewGrammar("goravel", "goravel_", "8.0.36", Name),
+			expectToBin:  "?",
+			expectToUuid: "`goravel_users`.`id`",
+		},
+		{
+			name:         "binary",
+			grammar:      NewGrammar("goravel", "goravel_", "8.0.36", Name),
+			uuid:         contracts.Uuid{Binary: true},
+			expectToBin:  "uuid_to_bin(?)",
+			expectToUuid: "bin_to_uuid(`goravel_users`.`id`)",
+		},
+		{
+			name:         "binary with swap flag",
+			grammar:      NewGrammar("goravel", "goravel_", "8.0.36", Name),
+			uuid:         contracts.Uuid{Binary: true, SwapFlag: true},
+			expectToBin:  "uuid_to_bin(?, 1)",
+			expectToUuid: "bin_to_uuid(`goravel_users`.`id`, 1)",
+		},
+		{
+			name:         "binary on MySQL 5.7",
+			grammar:      NewGrammar("goravel", "goravel_", "5.7.44", Name),
+			uuid:         contracts.Uuid{Binary: true, SwapFlag: true},
+			expectToBin:  "unhex(replace(?, '-', ''))",
+			expectToUuid: "lower(insert(insert(insert(insert(hex(`goravel_users`.`id`), 9, 0, '-'), 14, 0, '-'), 19, 0, '-'), 24, 0, '-'))",
+		},
+		{
+			name:         "binary on MariaDB",
+			grammar:      NewMariadbGrammar("goravel", "goravel_", "11.4.2").Grammar,
+			uuid:         contracts.Uuid{Binary: true, SwapFlag: true},
+			expectToBin:  "unhex(replace(?, '-', ''))",
+			expectToUuid: "lower(insert(insert(insert(insert(hex(`goravel_users`.`id`), 9, 0, '-'), 14, 0, '-'), 19, 0, '-'), 24, 0, '-'))",
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			test.grammar.uuid = test.uuid
+
+			sql, args := test.grammar.CompileUuidToBin(uuid)
+			s.Equal(test.expectToBin, sql)
+			s.Equal([]any{uuid}, args)
+			s.Equal(test.expectToUuid, test.grammar.CompileBinToUuid("users.id"))
+		})
+	}
+}
+
 func (s *GrammarSuite) TestTypeString() {
 	mockColumn1 := mocksdriver.NewColumnDefinition(s.T())
 	mockColumn1.EXPECT().GetLength().Return(100).Once()
@@ -795,6 +1412,9 @@ func (s *GrammarSuite) TestTypeUuid() {
 	mockColumn := mocksdriver.NewColumnDefinition(s.T())
 
 	s.Equal("char(36)", s.grammar.TypeUuid(mockColumn))
+
+	s.grammar.uuid = contracts.Uuid{Binary: true}
+	s.Equal("binary(16)", s.grammar.TypeUuid(mockColumn))
 }
 
 func TestGetCommandByName(t *testing.T) {
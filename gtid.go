@@ -0,0 +1,283 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/goravel/mysql/contracts"
+)
+
+const (
+	// ReadYourWritesWait Wait on the replica until the writes are replicated, or read from the writer on timeout.
+	ReadYourWritesWait = "wait"
+	// ReadYourWritesWriter Read from the writer once a write is made.
+	ReadYourWritesWriter = "writer"
+
+	defaultReadYourWritesTimeout  = time.Second
+	readYourWritesCallback        = "goravel:read_your_writes"
+	readYourWritesCaptureCallback = "goravel:read_your_writes_capture"
+)
+
+type readYourWritesKey struct{}
+
+// readYourWritesConnectionKey The key of the connection pinned by a write statement, the cloned statements don't
+// share it.
+type readYourWritesConnectionKey struct {
+	statement *gorm.Statement
+}
+
+// readYourWrites The writes made with a context, they are tracked by WithReadYourWrites.
+type readYourWrites struct {
+	mu sync.Mutex
+	// written reports whether a write has been made.
+	written bool
+	// unknown reports whether the GTID of a write can't be captured, for example, the write is in a transaction.
+	unknown bool
+	gtid    string
+}
+
+// WithReadYourWrites returns a context that tracks the writes made with it, the reads with the context see the
+// writes when read_your_writes is enabled for the connection. The context is returned as it is if it's tracked.
+func WithReadYourWrites(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(readYourWritesKey{}).(*readYourWrites); ok {
+		return ctx
+	}
+
+	return context.WithValue(ctx, readYourWritesKey{}, &readYourWrites{})
+}
+
+// gtidCallbacks The gorm callbacks that route the reads of a tracked context according to the GTIDs of the writes.
+// They are registered to the gorm instance of the writer, where dbresolver switches the connection pool of the
+// statements, so they run after the switching.
+type gtidCallbacks struct {
+	dialector *Dialector
+}
+
+func (r *gtidCallbacks) register(db *gorm.DB) error {
+	callback := db.Callback()
+	if err := callback.Create().Before("gorm:create").Register(readYourWritesCallback, r.beforeWrite); err != nil {
+		return err
+	}
+	if err := callback.Create().After("*").Register(readYourWritesCaptureCallback, r.afterWrite); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:update").Register(readYourWritesCallback, r.beforeWrite); err != nil {
+		return err
+	}
+	if err := callback.Update().After("*").Register(readYourWritesCaptureCallback, r.afterWrite); err != nil {
+		return err
+	}
+	if err := callback.Delete().Before("gorm:delete").Register(readYourWritesCallback, r.beforeWrite); err != nil {
+		return err
+	}
+	if err := callback.Delete().After("*").Register(readYourWritesCaptureCallback, r.afterWrite); err != nil {
+		return err
+	}
+	if err := callback.Query().Before("gorm:query").Register(readYourWritesCallback, r.beforeRead); err != nil {
+		return err
+	}
+	if err := callback.Row().Before("gorm:row").Register(readYourWritesCallback, r.beforeRead); err != nil {
+		return err
+	}
+	if err := callback.Raw().Before("gorm:raw").Register(readYourWritesCallback, r.beforeRaw); err != nil {
+		return err
+	}
+
+	return callback.Raw().After("*").Register(readYourWritesCaptureCallback, r.afterWrite)
+}
+
+// beforeWrite pins the write of a tracked context to a connection of the pool, so the GTID of the write is captured
+// from the session that makes it once the statement is done. The writes in a transaction aren't pinned, their GTIDs
+// are assigned on commit, so they are unknown.
+func (r *gtidCallbacks) beforeWrite(db *gorm.DB) {
+	if _, ok := db.Statement.Context.Value(readYourWritesKey{}).(*readYourWrites); !ok || db.Error != nil {
+		return
+	}
+
+	pool := db.Statement.ConnPool
+	if preparedStmtDB, ok := pool.(*gorm.PreparedStmtDB); ok {
+		pool = preparedStmtDB.ConnPool
+	}
+
+	var conn *sql.Conn
+	switch pool := pool.(type) {
+	case *sql.Conn:
+		// The nested writes, for example, of the associations, are captured by the statement that pins the connection.
+		return
+	case *sql.DB:
+		if pinned, err := pool.Conn(db.Statement.Context); err == nil {
+			conn = pinned
+			db.Statement.ConnPool = conn
+		}
+	}
+
+	db.Statement.Settings.Store(readYourWritesConnectionKey{statement: db.Statement}, conn)
+}
+
+func (r *gtidCallbacks) afterWrite(db *gorm.DB) {
+	value, ok := db.Statement.Settings.LoadAndDelete(readYourWritesConnectionKey{statement: db.Statement})
+	if !ok {
+		return
+	}
+
+	conn, _ := value.(*sql.Conn)
+	if conn != nil {
+		defer func() {
+			_ = conn.Close()
+		}()
+	}
+
+	state, ok := db.Statement.Context.Value(readYourWritesKey{}).(*readYourWrites)
+	if !ok || db.Error != nil {
+		return
+	}
+
+	var gtid string
+	if conn != nil {
+		gtid, _ = r.sessionGtid(db.Statement.Context, conn)
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	state.written = true
+	if gtid == "" {
+		state.unknown = true
+	} else {
+		state.gtid = r.mergeGtid(state.gtid, gtid)
+	}
+}
+
+// beforeRaw The raw statements that are sent to the writer are treated as writes, except the SELECT ones.
+func (r *gtidCallbacks) beforeRaw(db *gorm.DB) {
+	if r.isReplica(db.Statement.ConnPool) {
+		r.beforeRead(db)
+	} else if !isSelect(db.Statement.SQL.String()) {
+		r.beforeWrite(db)
+	}
+}
+
+func (r *gtidCallbacks) beforeRead(db *gorm.DB) {
+	state, ok := db.Statement.Context.Value(readYourWritesKey{}).(*readYourWrites)
+	if !ok || db.Error != nil || !r.isReplica(db.Statement.ConnPool) {
+		return
+	}
+
+	// The reader is chosen before waiting for the replication, so the statement is sent to the reader that has
+	// executed the writes.
+	if reader, ok := db.Statement.ConnPool.(*readerPool); ok {
+		db.Statement.ConnPool = reader.resolve()
+		if !r.isReplica(db.Statement.ConnPool) {
+			return
+		}
+	}
+
+	state.mu.Lock()
+	written, unknown, gtid := state.written, state.unknown, state.gtid
+	state.mu.Unlock()
+	if !written {
+		return
+	}
+
+	// The replication can't be waited if GTID is not enabled on the writer or the GTID of a write is unknown.
+	if r.dialector.fullConfig.ReadYourWrites.Mode == ReadYourWritesWriter || unknown || gtid == "" {
+		db.Statement.ConnPool = r.dialector.Conn
+		return
+	}
+
+	if replicated, err := r.wait(db.Statement.Context, db.Statement.ConnPool, gtid); err != nil || !replicated {
+		db.Statement.ConnPool = r.dialector.Conn
+	}
+}
+
+// sessionGtid returns the GTID of the last write made by the session of the connection, rather than the global one
+// that contains the writes of other sessions. It's empty if GTID is not enabled.
+func (r *gtidCallbacks) sessionGtid(ctx context.Context, conn *sql.Conn) (string, error) {
+	query := "SELECT @@SESSION.gtid_executed"
+	if r.isMariaDB() {
+		query = "SELECT @@SESSION.last_gtid"
+	}
+
+	var gtid sql.NullString
+	if err := conn.QueryRowContext(ctx, query).Scan(&gtid); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(gtid.String), nil
+}
+
+// mergeGtid adds the GTID of a write to the GTIDs of the context. The GTID sets of MySQL are joined, MariaDB keeps
+// the last GTID of each domain, the GTIDs of a domain are ordered.
+func (r *gtidCallbacks) mergeGtid(gtids, gtid string) string {
+	if gtids == "" {
+		return gtid
+	}
+	if !r.isMariaDB() {
+		return gtids + "," + gtid
+	}
+
+	domain, _, _ := strings.Cut(gtid, "-")
+	merged := slices.DeleteFunc(strings.Split(gtids, ","), func(existing string) bool {
+		return strings.HasPrefix(existing, domain+"-")
+	})
+
+	return strings.Join(append(merged, gtid), ",")
+}
+
+// wait waits until the GTID set is executed by the replica, false is returned on timeout.
+func (r *gtidCallbacks) wait(ctx context.Context, pool gorm.ConnPool, gtid string) (bool, error) {
+	timeout := r.dialector.fullConfig.ReadYourWrites.Timeout
+	if timeout <= 0 {
+		timeout = defaultReadYourWritesTimeout
+	}
+
+	// WAIT_FOR_EXECUTED_GTID_SET returns 1 on timeout, MASTER_GTID_WAIT returns -1.
+	query := "SELECT WAIT_FOR_EXECUTED_GTID_SET(?, ?)"
+	if r.isMariaDB() {
+		query = "SELECT MASTER_GTID_WAIT(?, ?)"
+	}
+
+	var result sql.NullInt64
+	if err := pool.QueryRowContext(ctx, query, gtid, timeout.Seconds()).Scan(&result); err != nil {
+		return false, err
+	}
+
+	return result.Valid && result.Int64 == 0, nil
+}
+
+func (r *gtidCallbacks) isMariaDB() bool {
+	return strings.Contains(r.dialector.ServerVersion, "MariaDB")
+}
+
+// isReplica reports whether the statement is sent to a replica, the transactions are always on the writer.
+func (r *gtidCallbacks) isReplica(pool gorm.ConnPool) bool {
+	if preparedStmtDB, ok := pool.(*gorm.PreparedStmtDB); ok {
+		pool = preparedStmtDB.ConnPool
+	}
+	if _, ok := pool.(gorm.TxCommitter); ok {
+		return false
+	}
+
+	return pool != nil && pool != r.dialector.Conn
+}
+
+// isSelect reports whether the raw statement is a SELECT one.
+func isSelect(sql string) bool {
+	sql = strings.TrimLeft(sql, " \t\r\n(")
+
+	return len(sql) >= 6 && strings.EqualFold(sql[:6], "select")
+}
+
+func validateReadYourWrites(readYourWrites contracts.ReadYourWrites) error {
+	if readYourWrites.Mode != "" && !slices.Contains([]string{ReadYourWritesWait, ReadYourWritesWriter}, readYourWrites.Mode) {
+		return InvalidReadYourWritesMode.Args(readYourWrites.Mode)
+	}
+
+	return nil
+}
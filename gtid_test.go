@@ -0,0 +1,200 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/goravel/mysql/contracts"
+)
+
+func TestWithReadYourWrites(t *testing.T) {
+	ctx := WithReadYourWrites(context.Background())
+	state, ok := ctx.Value(readYourWritesKey{}).(*readYourWrites)
+	assert.True(t, ok)
+	assert.False(t, state.written)
+
+	// The tracked context is returned as it is.
+	assert.Equal(t, ctx, WithReadYourWrites(ctx))
+}
+
+func TestGtidCallbacks(t *testing.T) {
+	tests := []struct {
+		name         string
+		mode         string
+		tracked      bool
+		written      bool
+		expectWriter bool
+	}{
+		{
+			name:    "the context isn't tracked",
+			mode:    ReadYourWritesWriter,
+			written: true,
+		},
+		{
+			name:    "no write is made",
+			mode:    ReadYourWritesWriter,
+			tracked: true,
+		},
+		{
+			name:         "read from the writer",
+			mode:         ReadYourWritesWriter,
+			tracked:      true,
+			written:      true,
+			expectWriter: true,
+		},
+		{
+			// The writer is unreachable, so the GTID can't be captured.
+			name:         "fall back to the writer when the GTID can't be captured",
+			mode:         ReadYourWritesWait,
+			tracked:      true,
+			written:      true,
+			expectWriter: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dialector, err := NewDialector(contracts.FullConfig{
+				Config:         contracts.Config{Host: "127.0.0.1", Port: 1},
+				ReadYourWrites: contracts.ReadYourWrites{Mode: test.mode},
+			})
+			assert.NoError(t, err)
+			dialector.SkipInitializeWithVersion = true
+
+			instance, err := gorm.Open(dialector, &gorm.Config{DisableAutomaticPing: true})
+			assert.NoError(t, err)
+			assert.NotNil(t, instance.Callback().Query().Get(readYourWritesCallback))
+
+			ctx := context.Background()
+			if test.tracked {
+				ctx = WithReadYourWrites(ctx)
+			}
+			callbacks := &gtidCallbacks{dialector: dialector}
+			replica := &testConnPool{name: "replica"}
+
+			if test.written {
+				write := instance.WithContext(ctx)
+				write.Statement.ConnPool = dialector.Conn
+				callbacks.beforeWrite(write)
+				callbacks.afterWrite(write)
+			}
+
+			read := instance.WithContext(ctx)
+			read.Statement.ConnPool = replica
+			callbacks.beforeRead(read)
+
+			if test.expectWriter {
+				assert.Equal(t, dialector.Conn, read.Statement.ConnPool)
+			} else {
+				assert.Equal(t, gorm.ConnPool(replica), read.Statement.ConnPool)
+			}
+		})
+	}
+}
+
+func TestGtidCallbacksWithReaderPool(t *testing.T) {
+	dialector, err := NewDialector(contracts.FullConfig{
+		Config:         contracts.Config{Host: "127.0.0.1", Port: 1},
+		ReadYourWrites: contracts.ReadYourWrites{Mode: ReadYourWritesWait},
+	})
+	assert.NoError(t, err)
+	dialector.SkipInitializeWithVersion = true
+
+	instance, err := gorm.Open(dialector, &gorm.Config{DisableAutomaticPing: true})
+	assert.NoError(t, err)
+
+	reader := &sql.DB{}
+	policy := NewReaderPolicy(nil)
+	policy.addWriter(dialector.Conn)
+	policy.addReader(reader, contracts.FullConfig{})
+
+	ctx := WithReadYourWrites(context.Background())
+	callbacks := &gtidCallbacks{dialector: dialector}
+
+	// The statement is pinned to the reader chosen by the policy.
+	read := instance.WithContext(ctx)
+	read.Statement.ConnPool = &readerPool{DB: reader, policy: policy}
+	callbacks.beforeRead(read)
+	assert.Equal(t, gorm.ConnPool(reader), read.Statement.ConnPool)
+
+	// No reader is healthy, so the policy routes the statement to the writer.
+	policy.readers[reader].healthy = false
+	read = instance.WithContext(ctx)
+	read.Statement.ConnPool = &readerPool{DB: reader, policy: policy}
+	callbacks.beforeRead(read)
+	assert.Equal(t, dialector.Conn, read.Statement.ConnPool)
+}
+
+func TestGtidCallbacksBeforeRaw(t *testing.T) {
+	dialector, err := NewDialector(contracts.FullConfig{
+		Config:         contracts.Config{Host: "127.0.0.1", Port: 1},
+		ReadYourWrites: contracts.ReadYourWrites{Mode: ReadYourWritesWriter},
+	})
+	assert.NoError(t, err)
+	dialector.SkipInitializeWithVersion = true
+
+	instance, err := gorm.Open(dialector, &gorm.Config{DisableAutomaticPing: true})
+	assert.NoError(t, err)
+
+	callbacks := &gtidCallbacks{dialector: dialector}
+	raw := func(ctx context.Context, sql string) {
+		db := instance.WithContext(ctx)
+		db.Statement.ConnPool = dialector.Conn
+		db.Statement.SQL.WriteString(sql)
+		callbacks.beforeRaw(db)
+		callbacks.afterWrite(db)
+	}
+
+	// The SELECT statements sent to the writer aren't writes.
+	ctx := WithReadYourWrites(context.Background())
+	raw(ctx, " (SELECT 1) UNION (SELECT 2)")
+	assert.False(t, ctx.Value(readYourWritesKey{}).(*readYourWrites).written)
+
+	raw(ctx, "UPDATE users SET name = 'goravel'")
+	assert.True(t, ctx.Value(readYourWritesKey{}).(*readYourWrites).written)
+}
+
+func TestGtidCallbacksMergeGtid(t *testing.T) {
+	dialector, err := NewDialector(contracts.FullConfig{Config: contracts.Config{Host: "127.0.0.1", Port: 3306}})
+	assert.NoError(t, err)
+
+	callbacks := &gtidCallbacks{dialector: dialector}
+	assert.Equal(t, "3e11fa47-71ca-11e1-9e33-c80aa9429562:5", callbacks.mergeGtid("", "3e11fa47-71ca-11e1-9e33-c80aa9429562:5"))
+	assert.Equal(t, "3e11fa47-71ca-11e1-9e33-c80aa9429562:5,3e11fa47-71ca-11e1-9e33-c80aa9429562:9",
+		callbacks.mergeGtid("3e11fa47-71ca-11e1-9e33-c80aa9429562:5", "3e11fa47-71ca-11e1-9e33-c80aa9429562:9"))
+
+	// MariaDB keeps the last GTID of each domain.
+	dialector.ServerVersion = "10.11.6-MariaDB"
+	assert.Equal(t, "0-1-9", callbacks.mergeGtid("0-1-5", "0-1-9"))
+	assert.Equal(t, "1-2-3,0-1-9", callbacks.mergeGtid("0-1-5,1-2-3", "0-1-9"))
+	assert.Equal(t, "0-1-5,10-1-2", callbacks.mergeGtid("0-1-5", "10-1-2"))
+}
+
+func TestGtidCallbacksIsReplica(t *testing.T) {
+	dialector, err := NewDialector(contracts.FullConfig{Config: contracts.Config{Host: "127.0.0.1", Port: 3306}})
+	assert.NoError(t, err)
+	dialector.SkipInitializeWithVersion = true
+
+	_, err = gorm.Open(dialector, &gorm.Config{DisableAutomaticPing: true})
+	assert.NoError(t, err)
+
+	callbacks := &gtidCallbacks{dialector: dialector}
+	replica := &testConnPool{name: "replica"}
+
+	assert.True(t, callbacks.isReplica(replica))
+	assert.True(t, callbacks.isReplica(&gorm.PreparedStmtDB{ConnPool: replica}))
+	assert.False(t, callbacks.isReplica(dialector.Conn))
+	assert.False(t, callbacks.isReplica(&gorm.PreparedStmtDB{ConnPool: dialector.Conn}))
+	assert.False(t, callbacks.isReplica(nil))
+}
+
+func TestValidateReadYourWrites(t *testing.T) {
+	assert.NoError(t, validateReadYourWrites(contracts.ReadYourWrites{}))
+	assert.NoError(t, validateReadYourWrites(contracts.ReadYourWrites{Mode: ReadYourWritesWait}))
+	assert.NoError(t, validateReadYourWrites(contracts.ReadYourWrites{Mode: ReadYourWritesWriter}))
+	assert.EqualError(t, validateReadYourWrites(contracts.ReadYourWrites{Mode: "strong"}), InvalidReadYourWritesMode.Args("strong").Error())
+}
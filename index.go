@@ -0,0 +1,50 @@
+package mysql
+
+// IndexPart A key part of an index, it's a column with an optional prefix length, or an expression.
+type IndexPart struct {
+	column     string
+	desc       bool
+	expression string
+	length     int
+}
+
+// IndexColumn returns a key part of the column.
+func IndexColumn(column string) IndexPart {
+	return IndexPart{column: column}
+}
+
+// IndexExpression returns a functional key part, the expression is raw SQL, e.g. "lower(`email`)". It requires
+// MySQL 8.0.13+.
+func IndexExpression(expression string) IndexPart {
+	return IndexPart{expression: expression}
+}
+
+// Desc sorts the key part in descending order, it's ignored by MySQL 5.7 and MariaDB before 10.8.
+func (r IndexPart) Desc() IndexPart {
+	r.desc = true
+
+	return r
+}
+
+// Length indexes the first length characters (bytes for the binary types) of the column.
+func (r IndexPart) Length(length int) IndexPart {
+	r.length = length
+
+	return r
+}
+
+// IndexParts replaces the columns of the index with the key parts, the index is found by its name:
+//
+//	table.Index("email").Name("users_email_lower_index")
+//	mysql.Table(table).IndexParts("users_email_lower_index", mysql.IndexExpression("lower(`email`)"))
+//
+//	table.Index("name", "created_at").Name("users_name_created_at_index")
+//	mysql.Table(table).IndexParts("users_name_created_at_index", mysql.IndexColumn("name").Length(50), mysql.IndexColumn("created_at").Desc())
+func (r *TableDefinition) IndexParts(index string, parts ...IndexPart) *TableDefinition {
+	if r.indexParts == nil {
+		r.indexParts = make(map[string][]IndexPart)
+	}
+	r.indexParts[index] = parts
+
+	return r
+}
@@ -0,0 +1,82 @@
+package mysql
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"gorm.io/gorm/clause"
+)
+
+// InsertIgnoreResult The rows that are inserted and ignored by an INSERT IGNORE statement.
+type InsertIgnoreResult struct {
+	Inserted int64
+	Ignored  int64
+}
+
+// NewInsertIgnoreResult derives the result from the affected rows of an INSERT IGNORE statement of rows rows, the rows
+// that conflict with an existing one or fail a constraint are skipped with a warning and not counted as affected.
+func NewInsertIgnoreResult(rows int, affected int64) InsertIgnoreResult {
+	return InsertIgnoreResult{
+		Inserted: affected,
+		Ignored:  max(int64(rows)-affected, 0),
+	}
+}
+
+// CompileInsertIgnore starts an INSERT IGNORE statement of the table, the rows that conflict with an existing one on a
+// unique key are skipped instead of failing the statement:
+//
+//	sql, args, err := grammar.CompileInsertIgnore("events").Columns("id", "payload").Values(id, payload).ToSql()
+func (r *Grammar) CompileInsertIgnore(table string) sq.InsertBuilder {
+	return sq.Insert(r.wrap.Table(table)).Options("IGNORE")
+}
+
+// CompileInsertIgnoreForGorm is the gorm clause of CompileInsertIgnore:
+//
+//	facades.Orm().Query().Instance().Clauses(grammar.CompileInsertIgnoreForGorm()).Create(&events)
+func (r *Grammar) CompileInsertIgnoreForGorm() clause.Expression {
+	return clause.Insert{Modifier: "IGNORE"}
+}
+
+// CompileReplace starts a REPLACE INTO statement of the table, the existing rows that conflict with the inserted one on
+// a unique key are deleted before it's inserted:
+//
+//	sql, args, err := grammar.CompileReplace("caches").Columns("id", "payload").Values(id, payload).ToSql()
+func (r *Grammar) CompileReplace(table string) sq.InsertBuilder {
+	return sq.Replace(r.wrap.Table(table))
+}
+
+// CompileReplaceForGorm is the gorm clause of CompileReplace:
+//
+//	facades.Orm().Query().Instance().Clauses(grammar.CompileReplaceForGorm()).Create(&caches)
+func (r *Grammar) CompileReplaceForGorm() clause.Expression {
+	return replaceClause{}
+}
+
+// CompileWarningCount returns the number of the warnings of the last statement, e.g. the rows skipped by INSERT IGNORE.
+// The count is kept per connection, so it should be selected in the same transaction as the statement.
+func (r *Grammar) CompileWarningCount() string {
+	return "select @@warning_count"
+}
+
+// CompileWarnings returns the warnings of the last statement, the result should be scanned into
+// []contracts.DBWarning. The warnings are kept per connection, so they should be selected in the same transaction as
+// the statement.
+func (r *Grammar) CompileWarnings() string {
+	return "show warnings"
+}
+
+// replaceClause replaces the INSERT clause of gorm with REPLACE INTO.
+type replaceClause struct{}
+
+func (r replaceClause) Name() string {
+	return "INSERT"
+}
+
+func (r replaceClause) Build(builder clause.Builder) {
+	builder.WriteString("REPLACE INTO ")
+	builder.WriteQuoted(clause.Table{Name: clause.CurrentTable})
+}
+
+// MergeClause clears the clause name, otherwise gorm writes INSERT before the expression.
+func (r replaceClause) MergeClause(clause *clause.Clause) {
+	clause.Name = ""
+	clause.Expression = r
+}
@@ -0,0 +1,49 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gormmysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type event struct {
+	ID      uint
+	Payload string
+}
+
+func TestCompileInsertIgnore(t *testing.T) {
+	grammar := NewGrammar("goravel", "goravel_", "8.4.3", Name)
+
+	sql, args, err := grammar.CompileInsertIgnore("events").Columns("id", "payload").Values(1, "a").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT IGNORE INTO `goravel_events` (id,payload) VALUES (?,?)", sql)
+	assert.Equal(t, []any{1, "a"}, args)
+
+	sql, args, err = grammar.CompileReplace("events").Columns("id", "payload").Values(1, "a").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "REPLACE INTO `goravel_events` (id,payload) VALUES (?,?)", sql)
+	assert.Equal(t, []any{1, "a"}, args)
+}
+
+func TestCompileInsertIgnoreForGorm(t *testing.T) {
+	grammar := NewGrammar("goravel", "goravel_", "8.4.3", Name)
+	instance, err := gorm.Open(gormmysql.New(gormmysql.Config{
+		DSN:                       "goravel:goravel@tcp(localhost:3306)/goravel",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DisableAutomaticPing: true, DryRun: true, SkipDefaultTransaction: true})
+	assert.NoError(t, err)
+
+	statement := instance.Clauses(grammar.CompileInsertIgnoreForGorm()).Create(&event{ID: 1, Payload: "a"}).Statement
+	assert.Equal(t, "INSERT IGNORE INTO `events` (`payload`,`id`) VALUES (?,?)", statement.SQL.String())
+
+	statement = instance.Clauses(grammar.CompileReplaceForGorm()).Create(&event{ID: 1, Payload: "a"}).Statement
+	assert.Equal(t, "REPLACE INTO `events` (`payload`,`id`) VALUES (?,?)", statement.SQL.String())
+}
+
+func TestNewInsertIgnoreResult(t *testing.T) {
+	assert.Equal(t, InsertIgnoreResult{Inserted: 3}, NewInsertIgnoreResult(3, 3))
+	assert.Equal(t, InsertIgnoreResult{Inserted: 1, Ignored: 2}, NewInsertIgnoreResult(3, 1))
+	assert.Equal(t, InsertIgnoreResult{Ignored: 3}, NewInsertIgnoreResult(3, 0))
+}
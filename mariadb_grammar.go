@@ -0,0 +1,155 @@
+package mysql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/goravel/framework/contracts/database/driver"
+)
+
+var _ driver.Grammar = &MariadbGrammar{}
+
+// MariadbGrammar The grammar of MariaDB, it's chosen by Mysql.Grammar when the server is MariaDB and overrides the
+// statements that are different from MySQL.
+type MariadbGrammar struct {
+	*Grammar
+}
+
+func NewMariadbGrammar(database, prefix, version string) *MariadbGrammar {
+	grammar := &MariadbGrammar{
+		Grammar: NewGrammar(database, prefix, version, "MariaDB"),
+	}
+	grammar.dialect = grammar
+
+	return grammar
+}
+
+// CompileBinToUuid compiles the UUID column to the UUID string, the native uuid type (10.7+) needs no conversion.
+func (r *MariadbGrammar) CompileBinToUuid(column string) string {
+	if r.nativeUuid() {
+		return r.wrap.Column(column)
+	}
+
+	return r.Grammar.CompileBinToUuid(column)
+}
+
+// CompileColumns reports the json columns as json, MariaDB creates them as longtext with a json_valid check.
+func (r *MariadbGrammar) CompileColumns(schema, table string) (string, error) {
+	// The table_name column of information_schema.check_constraints is added by MariaDB 10.2.22.
+	if !r.versionAtLeast(nil, semver.New(10, 2, 22, "", "")) {
+		return r.Grammar.CompileColumns(schema, table)
+	}
+
+	table = r.prefix + table
+
+	return fmt.Sprintf(
+		"select c.column_name as `name`, if(cc.constraint_name is null, c.data_type, 'json') as `type_name`, "+
+			"if(cc.constraint_name is null, c.column_type, 'json') as `type`, "+
+			"c.collation_name as `collation`, c.is_nullable as `nullable`, "+
+			"c.column_default as `default`, c.column_comment as `comment`, "+
+			"c.generation_expression as `expression`, c.extra as `extra` "+
+			"from information_schema.columns as c "+
+			"left join information_schema.check_constraints as cc "+
+			"on cc.constraint_schema = c.table_schema and cc.table_name = c.table_name and cc.constraint_name = c.column_name "+
+			"and cc.check_clause = concat('json_valid(`', c.column_name, '`)') "+
+			"where c.table_schema = %s and c.table_name = %s "+
+			"order by c.ordinal_position asc", r.wrap.Quote(r.database), r.wrap.Quote(table)), nil
+}
+
+// CompileCreate appends WITH SYSTEM VERSIONING to the table options if it's set via TableDefinition.SystemVersioning.
+func (r *MariadbGrammar) CompileCreate(blueprint driver.Blueprint) string {
+	sql := r.Grammar.CompileCreate(blueprint)
+	definition := tableDefinition(blueprint)
+	if definition == nil || !definition.systemVersioning {
+		return sql
+	}
+
+	partitioning := r.compilePartitioning(definition.partitioning)
+
+	return strings.TrimSuffix(sql, partitioning) + " with system versioning" + partitioning
+}
+
+// CompileCreateSequence creates a sequence, the next value is selected via "select nextval(`name`)".
+func (r *MariadbGrammar) CompileCreateSequence(name string, start, increment int64) string {
+	return fmt.Sprintf("create sequence %s start with %d increment by %d", r.wrap.Table(name), start, increment)
+}
+
+// CompileDropCheck drops the CHECK constraint, MariaDB doesn't support DROP CHECK.
+func (r *MariadbGrammar) CompileDropCheck(table, name string) (string, error) {
+	if err := r.checkConstraintSupported(); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("alter table %s drop constraint %s", r.wrap.Table(table), r.wrap.Column(name)), nil
+}
+
+func (r *MariadbGrammar) CompileDropSequence(name string) string {
+	return fmt.Sprintf("drop sequence if exists %s", r.wrap.Table(name))
+}
+
+// CompileJsonColumnsUpdate casts the values via json_extract, MariaDB doesn't support casting to JSON directly.
+func (r *MariadbGrammar) CompileJsonColumnsUpdate(values map[string]any) (map[string]any, error) {
+	return r.compileJsonColumnsUpdate(values, "json_extract(?, '$')")
+}
+
+// CompileReturning compiles the RETURNING clause that is appended to an insert statement, the inserted rows are
+// returned without selecting them again:
+//
+//	returning, err := grammar.CompileReturning("id", "created_at")
+//	facades.DB().Select(&users, "insert into users (name) values (?)"+returning, "goravel")
+func (r *MariadbGrammar) CompileReturning(columns ...string) (string, error) {
+	if !r.versionAtLeast(nil, semver.New(10, 5, 0, "", "")) {
+		return "", ReturningNotSupported.Args(r.version)
+	}
+
+	return " returning " + r.wrap.Columnize(columns), nil
+}
+
+func (r *MariadbGrammar) CompileSequences(database string) string {
+	return fmt.Sprintf("select table_name as `name` from information_schema.tables "+
+		"where table_schema = %s and table_type = 'SEQUENCE' "+
+		"order by table_name", r.wrap.Quote(database))
+}
+
+// CompileUuidToBin compiles the UUID string to the storage of the UUID columns, the native uuid type (10.7+) needs no
+// conversion.
+func (r *MariadbGrammar) CompileUuidToBin(uuid string) (string, []any) {
+	if r.nativeUuid() {
+		return "?", []any{uuid}
+	}
+
+	return r.Grammar.CompileUuidToBin(uuid)
+}
+
+// TypeIpAddress returns the native inet6 type (10.5+), it stores both IPv4 and IPv6 addresses.
+func (r *MariadbGrammar) TypeIpAddress(column driver.ColumnDefinition) string {
+	if r.versionAtLeast(nil, semver.New(10, 5, 0, "", "")) {
+		return "inet6"
+	}
+
+	return r.Grammar.TypeIpAddress(column)
+}
+
+// TypeUuid returns the native uuid type (10.7+) if the connection stores the UUID as binary.
+func (r *MariadbGrammar) TypeUuid(column driver.ColumnDefinition) string {
+	if r.nativeUuid() {
+		return "uuid"
+	}
+
+	return r.Grammar.TypeUuid(column)
+}
+
+// nativeUuid returns whether the UUID is stored as the native uuid type, it's added by MariaDB 10.7.
+func (r *MariadbGrammar) nativeUuid() bool {
+	return r.uuid.Binary && r.versionAtLeast(nil, semver.New(10, 7, 0, "", ""))
+}
+
+func (r *MariadbGrammar) requiredVersion(_, mariadb *semver.Version) *semver.Version {
+	return mariadb
+}
+
+// spatialSridKeyword MariaDB declares the SRID of a spatial column via REF_SYSTEM_ID.
+func (r *MariadbGrammar) spatialSridKeyword() string {
+	return "ref_system_id ="
+}
@@ -0,0 +1,259 @@
+package mysql
+
+import (
+	"testing"
+
+	contractsdriver "github.com/goravel/framework/contracts/database/driver"
+	databasedb "github.com/goravel/framework/database/db"
+	"github.com/goravel/framework/database/schema"
+	"github.com/goravel/framework/foundation/json"
+	mocksdriver "github.com/goravel/framework/mocks/database/driver"
+	mocksfoundation "github.com/goravel/framework/mocks/foundation"
+	"github.com/goravel/framework/process"
+	"github.com/goravel/framework/testing/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goravel/mysql/contracts"
+	mocks "github.com/goravel/mysql/mocks"
+)
+
+type MariadbGrammarSuite struct {
+	suite.Suite
+	grammar *MariadbGrammar
+}
+
+func TestMariadbGrammarSuite(t *testing.T) {
+	suite.Run(t, &MariadbGrammarSuite{})
+}
+
+func (s *MariadbGrammarSuite) SetupTest() {
+	s.grammar = NewMariadbGrammar("goravel", "goravel_", "11.4.2")
+}
+
+func (s *MariadbGrammarSuite) TestColumnTypes() {
+	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+	s.grammar.uuid = contracts.Uuid{Binary: true}
+
+	s.Equal("`id` uuid not null", s.grammar.getColumn(mockBlueprint, schema.NewColumnDefinition("id", "uuid")))
+	s.Equal("`ip` inet6 not null", s.grammar.getColumn(mockBlueprint, schema.NewColumnDefinition("ip", "ipAddress")))
+
+	s.grammar.version = "10.4.34"
+
+	s.Equal("`id` binary(16) not null", s.grammar.getColumn(mockBlueprint, schema.NewColumnDefinition("id", "uuid")))
+	s.Equal("`ip` varchar(45) not null", s.grammar.getColumn(mockBlueprint, schema.NewColumnDefinition("ip", "ipAddress")))
+}
+
+func (s *MariadbGrammarSuite) TestSpatialTypes() {
+	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+	column := schema.NewColumnDefinition("location", "point")
+
+	s.Equal("`location` point not null", s.grammar.getColumn(mockBlueprint, column))
+
+	Column(column).Srid(4326)
+
+	s.Equal("`location` point ref_system_id = 4326 not null", s.grammar.getColumn(mockBlueprint, column))
+	s.Equal("polygon ref_system_id = 4326", s.grammar.TypePolygon(Column(schema.NewColumnDefinition("area", "polygon")).Srid(4326)))
+}
+
+func (s *MariadbGrammarSuite) TestCompileColumns() {
+	sql, err := s.grammar.CompileColumns("", "users")
+	s.NoError(err)
+	s.Equal("select c.column_name as `name`, if(cc.constraint_name is null, c.data_type, 'json') as `type_name`, "+
+		"if(cc.constraint_name is null, c.column_type, 'json') as `type`, "+
+		"c.collation_name as `collation`, c.is_nullable as `nullable`, "+
+		"c.column_default as `default`, c.column_comment as `comment`, "+
+		"c.generation_expression as `expression`, c.extra as `extra` "+
+		"from information_schema.columns as c "+
+		"left join information_schema.check_constraints as cc "+
+		"on cc.constraint_schema = c.table_schema and cc.table_name = c.table_name and cc.constraint_name = c.column_name "+
+		"and cc.check_clause = concat('json_valid(`', c.column_name, '`)') "+
+		"where c.table_schema = 'goravel' and c.table_name = 'goravel_users' "+
+		"order by c.ordinal_position asc", sql)
+
+	// The default is the real default on the servers that rebuild the column to rename it
+	s.grammar.version = "10.4.34"
+	sql, err = s.grammar.CompileColumns("", "users")
+	s.NoError(err)
+	s.Contains(sql, "c.column_default as `default`")
+	s.NotContains(sql, "coalesce")
+
+	s.grammar.version = "10.1.48"
+	sql, err = s.grammar.CompileColumns("", "users")
+	s.NoError(err)
+	s.Contains(sql, "from information_schema.columns where table_schema = 'goravel'")
+}
+
+func (s *MariadbGrammarSuite) TestCompileCreate() {
+	blueprint := schema.NewBlueprint(nil, "goravel_", "users")
+	blueprint.Integer("id")
+
+	s.Equal("create table `goravel_users` (`id` int not null)", s.grammar.CompileCreate(blueprint))
+
+	Table(blueprint).SystemVersioning()
+
+	s.Equal("create table `goravel_users` (`id` int not null) with system versioning", s.grammar.CompileCreate(blueprint))
+
+	Table(blueprint).PartitionByHash("`id`", 4)
+
+	s.Equal("create table `goravel_users` (`id` int not null) with system versioning partition by hash (`id`) partitions 4",
+		s.grammar.CompileCreate(blueprint))
+}
+
+func (s *MariadbGrammarSuite) TestCompileDropCheck() {
+	sql, err := s.grammar.CompileDropCheck("users", "users_age_check")
+	s.NoError(err)
+	s.Equal("alter table `goravel_users` drop constraint `users_age_check`", sql)
+
+	s.grammar.version = "10.1.48"
+	_, err = s.grammar.CompileDropCheck("users", "users_age_check")
+	s.Equal(CheckConstraintNotSupported.Args("MariaDB", "10.1.48"), err)
+}
+
+func (s *MariadbGrammarSuite) TestCompileJsonColumnsUpdate() {
+	mockApp := mocksfoundation.NewApplication(s.T())
+	mockApp.EXPECT().GetJson().Return(json.New()).Once()
+
+	originApp := App
+	App = mockApp
+	s.T().Cleanup(func() {
+		App = originApp
+	})
+
+	values, err := s.grammar.CompileJsonColumnsUpdate(map[string]any{"data->details": []string{"a"}})
+	s.NoError(err)
+	s.Equal(map[string]any{
+		"data": databasedb.Raw("json_set(?,?,?)", databasedb.Raw("`data`"), `$."details"`, databasedb.Raw("json_extract(?, '$')", `["a"]`)),
+	}, values)
+}
+
+func (s *MariadbGrammarSuite) TestCompileRenameColumn() {
+	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+	mockBlueprint.EXPECT().GetTableName().Return("users").Once()
+
+	sql, err := s.grammar.CompileRenameColumn(mockBlueprint, &contractsdriver.Command{
+		From: "before",
+		To:   "after",
+	}, nil)
+	s.NoError(err)
+	s.Equal("alter table `goravel_users` rename column `before` to `after`", sql)
+
+	s.grammar.version = "10.4.34"
+	_, err = s.grammar.CompileRenameColumn(mockBlueprint, &contractsdriver.Command{
+		From: "before",
+		To:   "after",
+	}, nil)
+	s.EqualError(err, "Column before does not exist")
+}
+
+func (s *MariadbGrammarSuite) TestCompileReturning() {
+	sql, err := s.grammar.CompileReturning("id", "created_at")
+	s.NoError(err)
+	s.Equal(" returning `id`, `created_at`", sql)
+
+	s.grammar.version = "10.4.34"
+	_, err = s.grammar.CompileReturning("id")
+	s.Equal(ReturningNotSupported.Args("10.4.34"), err)
+}
+
+func (s *MariadbGrammarSuite) TestCompileSequences() {
+	s.Equal("create sequence `goravel_orders_seq` start with 1000 increment by 1", s.grammar.CompileCreateSequence("orders_seq", 1000, 1))
+	s.Equal("drop sequence if exists `goravel_orders_seq`", s.grammar.CompileDropSequence("orders_seq"))
+	s.Equal("select table_name as `name` from information_schema.tables "+
+		"where table_schema = 'goravel' and table_type = 'SEQUENCE' "+
+		"order by table_name", s.grammar.CompileSequences("goravel"))
+}
+
+func (s *MariadbGrammarSuite) TestCompileUuidConversions() {
+	uuid := "6ccd780c-baba-1026-9564-5b8c656024db"
+
+	sql, args := s.grammar.CompileUuidToBin(uuid)
+	s.Equal("?", sql)
+	s.Equal([]any{uuid}, args)
+	s.Equal("`goravel_users`.`id`", s.grammar.CompileBinToUuid("users.id"))
+
+	s.grammar.uuid = contracts.Uuid{Binary: true}
+
+	sql, _ = s.grammar.CompileUuidToBin(uuid)
+	s.Equal("?", sql)
+	s.Equal("`goravel_users`.`id`", s.grammar.CompileBinToUuid("users.id"))
+
+	s.grammar.version = "10.6.18"
+
+	sql, _ = s.grammar.CompileUuidToBin(uuid)
+	s.Equal("unhex(replace(?, '-', ''))", sql)
+	s.Equal("lower(insert(insert(insert(insert(hex(`goravel_users`.`id`), 9, 0, '-'), 14, 0, '-'), 19, 0, '-'), 24, 0, '-'))",
+		s.grammar.CompileBinToUuid("users.id"))
+}
+
+func TestMariadbGrammar(t *testing.T) {
+	t.Parallel()
+	writer := contracts.FullConfig{
+		Config: contracts.Config{
+			Host:     "localhost",
+			Database: "goravel",
+			Username: "goravel",
+			Password: "Framework!123",
+		},
+		Loc:     "UTC",
+		Charset: "utf8mb4",
+		Uuid:    contracts.Uuid{Binary: true},
+	}
+
+	docker := NewMariadbDocker(nil, process.New(), writer.Database, writer.Username, writer.Password)
+	require.NoError(t, docker.Build())
+
+	writer.Port = docker.databaseConfig.Port
+	instance, err := docker.connect()
+	require.NoError(t, err)
+
+	mockConfig := mocks.NewConfigBuilder(t)
+	mockConfig.EXPECT().Writers().Return([]contracts.FullConfig{writer})
+
+	mysql := &Mysql{
+		config: mockConfig,
+		log:    utils.NewTestLog(),
+	}
+	grammar, ok := mysql.Grammar().(*MariadbGrammar)
+	require.True(t, ok)
+
+	blueprint := schema.NewBlueprint(nil, "", "users")
+	blueprint.Create()
+	blueprint.Uuid("id")
+	blueprint.Json("data")
+	blueprint.Column("ip", "ipAddress")
+	Table(blueprint).SystemVersioning()
+
+	statements, err := blueprint.ToSql(grammar)
+	assert.NoError(t, err)
+	for _, statement := range statements {
+		assert.NoError(t, instance.Exec(statement).Error, statement)
+	}
+	assert.NoError(t, instance.Exec(grammar.CompileCreateSequence("users_seq", 1, 1)).Error)
+
+	id := "6ccd780c-baba-1026-9564-5b8c656024db"
+	uuidToBin, args := grammar.CompileUuidToBin(id)
+	assert.NoError(t, instance.Exec("insert into users (id, data, ip) values ("+uuidToBin+", '{}', '::1')", args...).Error)
+
+	sql, err := grammar.CompileColumns("", "users")
+	assert.NoError(t, err)
+	var dbColumns []contractsdriver.DBColumn
+	assert.NoError(t, instance.Raw(sql).Scan(&dbColumns).Error)
+	columns := NewProcessor().ProcessColumns(dbColumns)
+	require.Len(t, columns, 3)
+	assert.Equal(t, "uuid", columns[0].TypeName)
+	assert.Equal(t, "json", columns[1].TypeName)
+	assert.Equal(t, "inet6", columns[2].TypeName)
+
+	var sequences []string
+	assert.NoError(t, instance.Raw(grammar.CompileSequences(writer.Database)).Scan(&sequences).Error)
+	assert.Equal(t, []string{"users_seq"}, sequences)
+
+	var tables []contractsdriver.Table
+	assert.NoError(t, instance.Raw(grammar.CompileTables(writer.Database)).Scan(&tables).Error)
+	assert.Len(t, tables, 1)
+
+	assert.NoError(t, docker.close(instance))
+	assert.NoError(t, docker.Shutdown())
+}
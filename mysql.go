@@ -2,8 +2,13 @@ package mysql
 
 import (
 	"fmt"
+	"net"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 
+	gomysql "github.com/go-sql-driver/mysql"
 	"github.com/goravel/framework/contracts/config"
 	"github.com/goravel/framework/contracts/database"
 	contractsdriver "github.com/goravel/framework/contracts/database/driver"
@@ -12,17 +17,26 @@ import (
 	"github.com/goravel/framework/contracts/testing/docker"
 	"github.com/goravel/framework/errors"
 	"github.com/goravel/framework/support/str"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 
 	"github.com/goravel/mysql/contracts"
 )
 
 var _ contractsdriver.Driver = &Mysql{}
 
+// dsnParams The DSN parameters that are supported by go-sql-driver.
+var dsnParams = []string{
+	"allowAllFiles", "allowCleartextPasswords", "allowFallbackToPlaintext", "allowNativePasswords", "allowOldPasswords",
+	"charset", "checkConnLiveness", "clientFoundRows", "collation", "columnsWithAlias", "compress", "connectionAttributes",
+	"interpolateParams", "loc", "maxAllowedPacket", "multiStatements", "parseTime", "readTimeout", "rejectReadOnly",
+	"serverPubKey", "timeTruncate", "timeout", "tls", "writeTimeout",
+}
+
 type Mysql struct {
 	config  contracts.ConfigBuilder
 	log     log.Log
+	policy  *ReaderPolicy
 	process process.Process
 	version string
 }
@@ -31,6 +45,7 @@ func NewMysql(config config.Config, log log.Log, process process.Process, connec
 	return &Mysql{
 		config:  NewConfig(config, connection),
 		log:     log,
+		policy:  connectionReaderPolicy(connection, log),
 		process: process,
 	}
 }
@@ -51,28 +66,88 @@ func (r *Mysql) Docker() (docker.DatabaseDriver, error) {
 func (r *Mysql) Grammar() contractsdriver.Grammar {
 	version, name := r.versionAndName()
 
-	return NewGrammar(r.config.Writers()[0].Database, r.config.Writers()[0].Prefix, version, name)
+	writer := r.config.Writers()[0]
+	if name != Name {
+		grammar := NewMariadbGrammar(writer.Database, writer.Prefix, version)
+		r.configureGrammar(grammar.Grammar, writer)
+
+		return grammar
+	}
+
+	grammar := NewGrammar(writer.Database, writer.Prefix, version, name)
+	r.configureGrammar(grammar, writer)
+
+	return grammar
 }
 
+// Pool returns the nodes of the connection, an empty pool is returned and the error is logged if a dialector can't be
+// built, for example, the ssl ca file is invalid.
 func (r *Mysql) Pool() database.Pool {
-	return database.Pool{
-		Readers: r.fullConfigsToConfigs(r.config.Readers()),
-		Writers: r.fullConfigsToConfigs(r.config.Writers()),
+	readers, err := r.fullConfigsToConfigs(r.config.Readers())
+	if err != nil {
+		r.logError(err)
+		return database.Pool{}
+	}
+	writers, err := r.fullConfigsToConfigs(r.config.Writers())
+	if err != nil {
+		r.logError(err)
+		return database.Pool{}
 	}
+
+	pool := database.Pool{
+		Readers: readers,
+		Writers: writers,
+	}
+	attachPoolCallbacks(pool)
+	if r.policy != nil {
+		r.policy.attach(pool)
+	}
+
+	return pool
 }
 
 func (r *Mysql) Processor() contractsdriver.Processor {
 	return NewProcessor()
 }
 
-func (r *Mysql) fullConfigsToConfigs(fullConfigs []contracts.FullConfig) []database.Config {
+// Retrier returns the retrier of the transactions, it's configured by the retry config of the connection.
+func (r *Mysql) Retrier() *Retrier {
+	var retry contracts.Retry
+	if writers := r.config.Writers(); len(writers) > 0 {
+		retry = writers[0].Retry
+	}
+
+	return NewRetrier(retry, r.log)
+}
+
+// ReaderPolicy returns the policy that chooses a reader of Pool by weight and replication health, it's shared by the
+// Mysql instances of the connection.
+func (r *Mysql) ReaderPolicy() dbresolver.Policy {
+	return r.policy
+}
+
+// configureGrammar sets the schema defaults of the connection to the grammar.
+func (r *Mysql) configureGrammar(grammar *Grammar, writer contracts.FullConfig) {
+	grammar.coalesceAlters = writer.CoalesceAlters
+	grammar.collation = writer.Collation
+	grammar.engine = writer.Engine
+	grammar.onlineDDL = writer.OnlineDDL
+	grammar.uuid = writer.Uuid
+}
+
+func (r *Mysql) fullConfigsToConfigs(fullConfigs []contracts.FullConfig) ([]database.Config, error) {
 	configs := make([]database.Config, len(fullConfigs))
 	for i, fullConfig := range fullConfigs {
+		dialector, err := fullConfigToDialector(fullConfig)
+		if err != nil {
+			return nil, FailedToBuildDialector.Args(fullConfig.Connection, err)
+		}
+
 		configs[i] = database.Config{
 			Connection:   fullConfig.Connection,
 			Dsn:          fullConfig.Dsn,
 			Database:     fullConfig.Database,
-			Dialector:    fullConfigToDialector(fullConfig),
+			Dialector:    dialector,
 			Driver:       Name,
 			Host:         fullConfig.Host,
 			NameReplacer: fullConfig.NameReplacer,
@@ -85,7 +160,13 @@ func (r *Mysql) fullConfigsToConfigs(fullConfigs []contracts.FullConfig) []datab
 		}
 	}
 
-	return configs
+	return configs, nil
+}
+
+func (r *Mysql) logError(err error) {
+	if r.log != nil {
+		r.log.Error(err)
+	}
 }
 
 func (r *Mysql) versionAndName() (string, string) {
@@ -101,8 +182,9 @@ func (r *Mysql) getVersion() string {
 		return r.version
 	}
 
-	writers := r.Pool().Writers
-	if len(writers) == 0 {
+	// The connection pool is closed after querying, so it's not registered to the reader policy.
+	writers, err := r.fullConfigsToConfigs(r.config.Writers())
+	if err != nil || len(writers) == 0 {
 		return ""
 	}
 
@@ -131,25 +213,136 @@ func (r *Mysql) getVersion() string {
 	return r.version
 }
 
-func dsn(fullConfig contracts.FullConfig) string {
+func dsnConfig(fullConfig contracts.FullConfig) (*gomysql.Config, error) {
+	if fullConfig.Dsn == "" && fullConfig.Host == "" && fullConfig.Socket == "" {
+		return nil, nil
+	}
+
+	// The parameters are formatted and then parsed by go-sql-driver, so the typed fields of gomysql.Config
+	// are filled by the driver itself, and the parameters of a user-supplied DSN are kept.
+	params, err := url.ParseQuery(dsnQuery(fullConfig.Dsn))
+	if err != nil {
+		return nil, err
+	}
+	if fullConfig.Dsn == "" {
+		params.Set("parseTime", "true")
+		params.Set("multiStatements", "true")
+	}
+	if fullConfig.Charset != "" && !params.Has("charset") {
+		params.Set("charset", fullConfig.Charset)
+	}
+	if fullConfig.Loc != "" && !params.Has("loc") {
+		params.Set("loc", fullConfig.Loc)
+	}
+
+	tlsName, err := registerTLSConfig(fullConfig)
+	if err != nil {
+		return nil, err
+	}
+	if tlsName != "" {
+		params.Set("tls", tlsName)
+	}
+	if fullConfig.Sslmode == SslmodePreferred {
+		params.Set("allowFallbackToPlaintext", "true")
+	}
+
+	for key, value := range fullConfig.Params {
+		if !slices.Contains(dsnParams, key) {
+			return nil, InvalidDsnParam.Args(key)
+		}
+		params.Set(key, value)
+	}
+
+	config, err := gomysql.ParseDSN("/?" + encodeDsnParams(params))
+	if err != nil {
+		return nil, err
+	}
+
 	if fullConfig.Dsn != "" {
-		return fullConfig.Dsn
+		dsnConfig, err := gomysql.ParseDSN(fullConfig.Dsn)
+		if err != nil {
+			return nil, err
+		}
+
+		config.User = dsnConfig.User
+		config.Passwd = dsnConfig.Passwd
+		config.Net = dsnConfig.Net
+		config.Addr = dsnConfig.Addr
+		config.DBName = dsnConfig.DBName
+
+		return config, nil
 	}
-	if fullConfig.Host == "" {
-		return ""
+
+	config.User = fullConfig.Username
+	config.Passwd = fullConfig.Password
+	config.DBName = fullConfig.Database
+	if fullConfig.Socket != "" {
+		config.Net = "unix"
+		config.Addr = fullConfig.Socket
+	} else {
+		config.Net = "tcp"
+		config.Addr = net.JoinHostPort(fullConfig.Host, strconv.Itoa(fullConfig.Port))
 	}
 
-	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=%s&parseTime=%t&loc=%s&multiStatements=true",
-		fullConfig.Username, fullConfig.Password, fullConfig.Host, fullConfig.Port, fullConfig.Database, fullConfig.Charset, true, url.QueryEscape(fullConfig.Loc))
+	return config, nil
+}
+
+// dsnQuery returns the parameters part of a DSN, the password and the address might contain a '/',
+// so the parameters are found after the last '/'.
+func dsnQuery(dsn string) string {
+	_, query, _ := strings.Cut(dsn[strings.LastIndex(dsn, "/")+1:], "?")
+
+	return query
+}
+
+// encodeDsnParams encodes the parameters like url.Values.Encode, except charset: go-sql-driver splits
+// it by ',' without unescaping it.
+func encodeDsnParams(params url.Values) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		value := params.Get(key)
+		if key != "charset" {
+			value = url.QueryEscape(value)
+		}
+		pairs = append(pairs, url.QueryEscape(key)+"="+value)
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+// attachPoolCallbacks shares the callbacks that restore the pool configuration among the nodes, they are attached only
+// if a node configures its pool.
+func attachPoolCallbacks(pool database.Pool) {
+	var dialectors []*Dialector
+	configured := false
+	for _, config := range slices.Concat(pool.Writers, pool.Readers) {
+		if dialector, ok := config.Dialector.(*Dialector); ok {
+			dialectors = append(dialectors, dialector)
+			configured = configured || dialector.Pool() != (contracts.Pool{})
+		}
+	}
+	if !configured {
+		return
+	}
+
+	callbacks := &poolCallbacks{}
+	for _, dialector := range dialectors {
+		dialector.pool = callbacks
+	}
 }
 
-func fullConfigToDialector(fullConfig contracts.FullConfig) gorm.Dialector {
-	dsn := dsn(fullConfig)
-	if dsn == "" {
-		return nil
+func fullConfigToDialector(fullConfig contracts.FullConfig) (gorm.Dialector, error) {
+	dialector, err := NewDialector(fullConfig)
+	if err != nil || dialector == nil {
+		// Avoid returning a typed nil dialector
+		return nil, err
 	}
 
-	return mysql.New(mysql.Config{
-		DSN: dsn,
-	})
+	return dialector, nil
 }
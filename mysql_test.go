@@ -1,13 +1,19 @@
 package mysql
 
 import (
+	"context"
+	"database/sql"
 	"testing"
 
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/goravel/framework/contracts/database"
+	mockslog "github.com/goravel/framework/mocks/log"
 	"github.com/goravel/framework/process"
 	"github.com/goravel/framework/testing/utils"
 	"github.com/goravel/mysql/contracts"
 	mocks "github.com/goravel/mysql/mocks"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 func TestVersion(t *testing.T) {
@@ -34,7 +40,6 @@ func TestVersion(t *testing.T) {
 
 	mockConfig := mocks.NewConfigBuilder(t)
 	mockConfig.EXPECT().Writers().Return(writes).Once()
-	mockConfig.EXPECT().Readers().Return([]contracts.FullConfig{}).Once()
 
 	mysql := &Mysql{
 		config: mockConfig,
@@ -70,7 +75,6 @@ func TestIssue706(t *testing.T) {
 
 	mockConfig := mocks.NewConfigBuilder(t)
 	mockConfig.EXPECT().Writers().Return(writes).Once()
-	mockConfig.EXPECT().Readers().Return([]contracts.FullConfig{}).Once()
 
 	mysql := &Mysql{
 		config: mockConfig,
@@ -80,3 +84,276 @@ func TestIssue706(t *testing.T) {
 	assert.Contains(t, version, ".")
 	assert.NoError(t, docker.Shutdown())
 }
+
+func TestSession(t *testing.T) {
+	t.Parallel()
+	fullConfig := contracts.FullConfig{
+		Config: contracts.Config{
+			Host:     "localhost",
+			Database: "goravel",
+			Username: "goravel",
+			Password: "Framework!123",
+			Pool:     contracts.Pool{MaxIdleConns: 2},
+		},
+		Loc:     "UTC",
+		Charset: "utf8mb4",
+		Session: contracts.Session{
+			SqlMode:              "STRICT_ALL_TABLES",
+			TimeZone:             "+08:00",
+			TransactionIsolation: "READ COMMITTED",
+			Collation:            "utf8mb4_bin",
+			Statements:           []string{"SET @goravel = 'session'"},
+		},
+	}
+
+	docker := NewDocker(nil, process.New(), fullConfig.Database, fullConfig.Username, fullConfig.Password)
+	assert.NoError(t, docker.Build())
+
+	fullConfig.Port = docker.databaseConfig.Port
+	_, err := docker.connect()
+	assert.NoError(t, err)
+
+	dialector, err := NewDialector(fullConfig)
+	assert.NoError(t, err)
+
+	instance, err := gorm.Open(dialector)
+	assert.NoError(t, err)
+
+	db, err := instance.DB()
+	assert.NoError(t, err)
+
+	// Hold two connections at the same time, so both of them are fresh physical connections of the pool.
+	ctx := context.Background()
+	conn1, err := db.Conn(ctx)
+	assert.NoError(t, err)
+	conn2, err := db.Conn(ctx)
+	assert.NoError(t, err)
+
+	for _, conn := range []*sql.Conn{conn1, conn2} {
+		var sqlMode, timeZone, isolation, collation, variable string
+		assert.NoError(t, conn.QueryRowContext(ctx, "SELECT @@session.sql_mode, @@session.time_zone, @@session.transaction_isolation, @@session.collation_connection, @goravel").
+			Scan(&sqlMode, &timeZone, &isolation, &collation, &variable))
+		assert.Equal(t, "STRICT_ALL_TABLES", sqlMode)
+		assert.Equal(t, "+08:00", timeZone)
+		assert.Equal(t, "READ-COMMITTED", isolation)
+		assert.Equal(t, "utf8mb4_bin", collation)
+		assert.Equal(t, "session", variable)
+		assert.NoError(t, conn.Close())
+	}
+
+	assert.NoError(t, db.Close())
+	assert.NoError(t, docker.Shutdown())
+}
+
+func TestPoolWithInvalidDialector(t *testing.T) {
+	mockConfig := mocks.NewConfigBuilder(t)
+	mockConfig.EXPECT().Readers().Return(nil).Once()
+	mockConfig.EXPECT().Writers().Return([]contracts.FullConfig{
+		{
+			Connection: "mysql",
+			Config:     contracts.Config{Host: "localhost", Port: 3306, Sslmode: "require"},
+		},
+	}).Once()
+
+	mockLog := mockslog.NewLog(t)
+	mockLog.EXPECT().Error(FailedToBuildDialector.Args("mysql", InvalidSslmode.Args("require"))).Once()
+
+	mysql := &Mysql{config: mockConfig, log: mockLog}
+
+	assert.Equal(t, database.Pool{}, mysql.Pool())
+}
+
+func TestDsnConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		fullConfig contracts.FullConfig
+		expectDsn  string
+		expectErr  bool
+	}{
+		{
+			name:       "host is empty",
+			fullConfig: contracts.FullConfig{},
+		},
+		{
+			name: "dsn is set",
+			fullConfig: contracts.FullConfig{
+				Config: contracts.Config{Dsn: "root:123123@tcp(localhost:3306)/goravel?timeout=5s"},
+			},
+			expectDsn: "root:123123@tcp(localhost:3306)/goravel?timeout=5s",
+		},
+		{
+			name: "dsn is set with charset and loc",
+			fullConfig: contracts.FullConfig{
+				Config:  contracts.Config{Dsn: "root:123123@tcp(localhost:3306)/goravel?loc=Asia%2FTokyo&parseTime=true"},
+				Charset: "utf8mb4",
+				Loc:     "Asia/Shanghai",
+			},
+			expectDsn: "root:123123@tcp(localhost:3306)/goravel?charset=utf8mb4&loc=Asia%2FTokyo&parseTime=true",
+		},
+		{
+			name: "dsn is invalid",
+			fullConfig: contracts.FullConfig{
+				Config: contracts.Config{Dsn: "root:123123@tcp(localhost:3306)"},
+			},
+			expectErr: true,
+		},
+		{
+			name: "without ssl",
+			fullConfig: contracts.FullConfig{
+				Config:  contracts.Config{Host: "localhost", Port: 3306, Database: "goravel", Username: "root", Password: "123123"},
+				Charset: "utf8mb4",
+				Loc:     "Asia/Shanghai",
+			},
+			expectDsn: "root:123123@tcp(localhost:3306)/goravel?charset=utf8mb4&loc=Asia%2FShanghai&multiStatements=true&parseTime=true",
+		},
+		{
+			name: "with ipv6 host",
+			fullConfig: contracts.FullConfig{
+				Config:  contracts.Config{Host: "::1", Port: 3306, Database: "goravel", Username: "root", Password: "123123"},
+				Charset: "utf8mb4",
+				Loc:     "UTC",
+			},
+			expectDsn: "root:123123@tcp([::1]:3306)/goravel?charset=utf8mb4&multiStatements=true&parseTime=true",
+		},
+		{
+			name: "with multiple charsets",
+			fullConfig: contracts.FullConfig{
+				Config:  contracts.Config{Host: "localhost", Port: 3306, Database: "goravel", Username: "root", Password: "123123"},
+				Charset: "utf8mb4,utf8",
+				Loc:     "UTC",
+			},
+			expectDsn: "root:123123@tcp(localhost:3306)/goravel?charset=utf8mb4,utf8&multiStatements=true&parseTime=true",
+		},
+		{
+			name: "with socket",
+			fullConfig: contracts.FullConfig{
+				Config:  contracts.Config{Host: "localhost", Port: 3306, Socket: "/var/run/mysqld/mysqld.sock", Database: "goravel", Username: "root", Password: "123123"},
+				Charset: "utf8mb4",
+				Loc:     "UTC",
+			},
+			expectDsn: "root:123123@unix(/var/run/mysqld/mysqld.sock)/goravel?charset=utf8mb4&multiStatements=true&parseTime=true",
+		},
+		{
+			name: "with socket but without host",
+			fullConfig: contracts.FullConfig{
+				Config:  contracts.Config{Socket: "/var/run/mysqld/mysqld.sock", Database: "goravel", Username: "root", Password: "123123"},
+				Charset: "utf8mb4",
+				Loc:     "UTC",
+			},
+			expectDsn: "root:123123@unix(/var/run/mysqld/mysqld.sock)/goravel?charset=utf8mb4&multiStatements=true&parseTime=true",
+		},
+		{
+			name: "with params",
+			fullConfig: contracts.FullConfig{
+				Config: contracts.Config{Host: "localhost", Port: 3306, Database: "goravel", Username: "root", Password: "123123", Params: map[string]string{
+					"timeout":              "5s",
+					"collation":            "utf8mb4_bin",
+					"parseTime":            "false",
+					"connectionAttributes": "program_name:goravel,env:a&b",
+				}},
+				Charset: "utf8mb4",
+				Loc:     "UTC",
+			},
+			expectDsn: "root:123123@tcp(localhost:3306)/goravel?charset=utf8mb4&collation=utf8mb4_bin&multiStatements=true&timeout=5s",
+		},
+		{
+			name: "with invalid params",
+			fullConfig: contracts.FullConfig{
+				Config: contracts.Config{Host: "localhost", Port: 3306, Params: map[string]string{"sql_mode": "TRADITIONAL"}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "with invalid param value",
+			fullConfig: contracts.FullConfig{
+				Config: contracts.Config{Host: "localhost", Port: 3306, Params: map[string]string{"timeout": "five"}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "ssl is disabled",
+			fullConfig: contracts.FullConfig{
+				Config:  contracts.Config{Host: "localhost", Port: 3306, Database: "goravel", Username: "root", Password: "123123", Sslmode: SslmodeDisabled},
+				Charset: "utf8mb4",
+				Loc:     "UTC",
+			},
+			expectDsn: "root:123123@tcp(localhost:3306)/goravel?charset=utf8mb4&multiStatements=true&parseTime=true&tls=false",
+		},
+		{
+			name: "ssl is preferred",
+			fullConfig: contracts.FullConfig{
+				Connection: "mysql",
+				Config:     contracts.Config{Host: "localhost", Port: 3306, Database: "goravel", Username: "root", Password: "123123", Sslmode: SslmodePreferred},
+				Charset:    "utf8mb4",
+				Loc:        "UTC",
+			},
+			expectDsn: "root:123123@tcp(localhost:3306)/goravel?allowFallbackToPlaintext=true&charset=utf8mb4&multiStatements=true&parseTime=true&tls=" + tlsConfigName(contracts.FullConfig{
+				Connection: "mysql",
+				Config:     contracts.Config{Host: "localhost", Port: 3306, Sslmode: SslmodePreferred},
+			}),
+		},
+		{
+			name: "ssl is invalid",
+			fullConfig: contracts.FullConfig{
+				Config: contracts.Config{Host: "localhost", Port: 3306, Sslmode: "invalid"},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := dsnConfig(test.fullConfig)
+			assert.Equal(t, test.expectErr, err != nil)
+			if test.expectDsn == "" {
+				assert.Nil(t, config)
+			} else {
+				assert.Equal(t, test.expectDsn, config.FormatDSN())
+			}
+		})
+	}
+}
+
+func TestDsnConfigWithHostilePassword(t *testing.T) {
+	passwords := []string{
+		"p@ssword",
+		"pass/word",
+		"pass:word",
+		"pass?word=1&a=b",
+		"p@ss/w:rd?",
+		"pass(word)",
+		"pass)@tcp(evil:3306)/db?",
+		"%2F%40",
+		"pass word#",
+	}
+
+	for _, password := range passwords {
+		t.Run(password, func(t *testing.T) {
+			config, err := dsnConfig(contracts.FullConfig{
+				Config:  contracts.Config{Host: "localhost", Port: 3306, Database: "goravel", Username: "root", Password: password},
+				Charset: "utf8mb4",
+				Loc:     "UTC",
+			})
+			assert.NoError(t, err)
+
+			parsedConfig, err := gomysql.ParseDSN(config.FormatDSN())
+			assert.NoError(t, err)
+			assert.Equal(t, "root", parsedConfig.User)
+			assert.Equal(t, password, parsedConfig.Passwd)
+			assert.Equal(t, "tcp", parsedConfig.Net)
+			assert.Equal(t, "localhost:3306", parsedConfig.Addr)
+			assert.Equal(t, "goravel", parsedConfig.DBName)
+		})
+	}
+
+	t.Run("user-supplied dsn", func(t *testing.T) {
+		config, err := dsnConfig(contracts.FullConfig{
+			Config:  contracts.Config{Dsn: "root:p@ss/w:rd?@tcp(localhost:3306)/goravel?parseTime=true"},
+			Charset: "utf8mb4",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "p@ss/w:rd?", config.Passwd)
+		assert.Equal(t, "goravel", config.DBName)
+		assert.True(t, config.ParseTime)
+	})
+}
@@ -0,0 +1,88 @@
+package mysql
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	PartitionMethodHash         = "hash"
+	PartitionMethodKey          = "key"
+	PartitionMethodList         = "list"
+	PartitionMethodRange        = "range"
+	PartitionMethodRangeColumns = "range columns"
+)
+
+// Partition A partition of a RANGE or LIST partitioned table.
+type Partition struct {
+	Name string
+	// Values The VALUES clause of the partition, e.g. "less than (2025)", "less than maxvalue", "in (1, 2)".
+	Values string
+}
+
+// PartitionLessThan returns a partition of a RANGE partitioned table, the value is an expression, e.g. "2025" or
+// "'2025-01-01'", it's a list of values for RANGE COLUMNS.
+func PartitionLessThan(name, value string) Partition {
+	return Partition{Name: name, Values: fmt.Sprintf("less than (%s)", value)}
+}
+
+// PartitionLessThanMaxValue returns the last partition of a RANGE partitioned table.
+func PartitionLessThanMaxValue(name string) Partition {
+	return Partition{Name: name, Values: "less than maxvalue"}
+}
+
+// PartitionIn returns a partition of a LIST partitioned table.
+func PartitionIn(name string, values ...string) Partition {
+	return Partition{Name: name, Values: fmt.Sprintf("in (%s)", strings.Join(values, ", "))}
+}
+
+type partitioning struct {
+	// columns The partitioning columns of RANGE COLUMNS and KEY, they are wrapped when compiling.
+	columns    []string
+	count      int
+	expression string
+	method     string
+	partitions []Partition
+}
+
+// PartitionByHash partitions the table into count partitions by the hash of the expression.
+func (r *TableDefinition) PartitionByHash(expression string, count int) *TableDefinition {
+	r.partitioning = &partitioning{method: PartitionMethodHash, expression: expression, count: count}
+
+	return r
+}
+
+// PartitionByKey partitions the table into count partitions by the columns, the primary key is used when the
+// columns are empty.
+func (r *TableDefinition) PartitionByKey(columns []string, count int) *TableDefinition {
+	r.partitioning = &partitioning{method: PartitionMethodKey, columns: columns, count: count}
+
+	return r
+}
+
+// PartitionByList partitions the table by the values of the expression:
+//
+//	mysql.Table(table).PartitionByList("`region_id`", mysql.PartitionIn("p_east", "1", "2"), mysql.PartitionIn("p_west", "3"))
+func (r *TableDefinition) PartitionByList(expression string, partitions ...Partition) *TableDefinition {
+	r.partitioning = &partitioning{method: PartitionMethodList, expression: expression, partitions: partitions}
+
+	return r
+}
+
+// PartitionByRange partitions the table by the ranges of the expression:
+//
+//	mysql.Table(table).PartitionByRange("year(`created_at`)", mysql.PartitionLessThan("p2025", "2026"), mysql.PartitionLessThanMaxValue("pmax"))
+func (r *TableDefinition) PartitionByRange(expression string, partitions ...Partition) *TableDefinition {
+	r.partitioning = &partitioning{method: PartitionMethodRange, expression: expression, partitions: partitions}
+
+	return r
+}
+
+// PartitionByRangeColumns partitions the table by the ranges of the columns, the columns needn't be integers:
+//
+//	mysql.Table(table).PartitionByRangeColumns([]string{"created_at"}, mysql.PartitionLessThan("p202501", "'2025-02-01'"))
+func (r *TableDefinition) PartitionByRangeColumns(columns []string, partitions ...Partition) *TableDefinition {
+	r.partitioning = &partitioning{method: PartitionMethodRangeColumns, columns: columns, partitions: partitions}
+
+	return r
+}
@@ -0,0 +1,330 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"math/rand/v2"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/goravel/framework/contracts/database"
+	"github.com/goravel/framework/contracts/log"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/goravel/mysql/contracts"
+)
+
+var _ dbresolver.Policy = &ReaderPolicy{}
+
+// readerPolicies The reader policies of the connections, the Mysql instances of a connection share the policy, so
+// Mysql.ReaderPolicy returns the one that the connection pool built by the framework is registered to.
+var readerPolicies sync.Map
+
+// replicaLagColumns The lag columns of SHOW REPLICA STATUS (MySQL 8.0.22+) and SHOW SLAVE STATUS.
+var replicaLagColumns = []string{"Seconds_Behind_Source", "Seconds_Behind_Master"}
+
+// ReaderPolicy chooses a reader by weight, the readers that are unhealthy or lag behind the source more than
+// ReplicaCheck.MaxLag are skipped, and the writer is used when no reader is available.
+//
+// The nodes are registered by the dialectors of Mysql.Pool when their connection pools are opened, the connection
+// pools that are not registered are treated as healthy with weight 1. The framework registers dbresolver with a random
+// policy, so the readers of Mysql.Pool route every statement via the policy rather than the chosen reader.
+type ReaderPolicy struct {
+	// connection The connection that the policy belongs to, it's empty if the policy isn't shared.
+	connection string
+	log        log.Log
+	// lag returns the replication lag of a reader, it's replaceable in tests.
+	lag func(ctx context.Context, pool gorm.ConnPool) (time.Duration, error)
+
+	mu      sync.RWMutex
+	readers map[gorm.ConnPool]*readerNode
+	writer  gorm.ConnPool
+	stop    chan struct{}
+}
+
+type readerNode struct {
+	fullConfig contracts.FullConfig
+	healthy    bool
+}
+
+func NewReaderPolicy(log log.Log) *ReaderPolicy {
+	return &ReaderPolicy{
+		log:     log,
+		lag:     replicaLag,
+		readers: make(map[gorm.ConnPool]*readerNode),
+	}
+}
+
+// connectionReaderPolicy returns the reader policy that is shared by the Mysql instances of the connection.
+func connectionReaderPolicy(connection string, log log.Log) *ReaderPolicy {
+	if policy, ok := readerPolicies.Load(connection); ok {
+		return policy.(*ReaderPolicy)
+	}
+
+	policy := NewReaderPolicy(log)
+	policy.connection = connection
+	actual, _ := readerPolicies.LoadOrStore(connection, policy)
+
+	return actual.(*ReaderPolicy)
+}
+
+func (r *ReaderPolicy) Resolve(connPools []gorm.ConnPool) gorm.ConnPool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var (
+		candidates []gorm.ConnPool
+		weights    []int
+		total      int
+	)
+	for _, pool := range connPools {
+		weight := 1
+		if node, ok := r.readers[pool]; ok {
+			if !node.healthy {
+				continue
+			}
+			weight = max(node.fullConfig.Weight, 1)
+		}
+
+		candidates = append(candidates, pool)
+		weights = append(weights, weight)
+		total += weight
+	}
+
+	if len(candidates) == 0 {
+		if r.writer != nil {
+			return r.writer
+		}
+
+		return connPools[rand.IntN(len(connPools))]
+	}
+
+	n := rand.IntN(total)
+	for i, weight := range weights {
+		if n < weight {
+			return candidates[i]
+		}
+		n -= weight
+	}
+
+	return candidates[len(candidates)-1]
+}
+
+// Close stops the health check of the readers and unregisters the nodes, it's called when the framework closes the
+// writer of the connection, the connection gets a new policy when it's built again.
+func (r *ReaderPolicy) Close() {
+	r.mu.Lock()
+	if r.stop != nil {
+		close(r.stop)
+		r.stop = nil
+	}
+	r.readers = make(map[gorm.ConnPool]*readerNode)
+	r.writer = nil
+	r.mu.Unlock()
+
+	if r.connection != "" {
+		readerPolicies.CompareAndDelete(r.connection, r)
+	}
+}
+
+// attach makes the dialectors of the pool register their connection pools once they are opened.
+func (r *ReaderPolicy) attach(pool database.Pool) {
+	for _, config := range pool.Readers {
+		if dialector, ok := config.Dialector.(*Dialector); ok {
+			dialector.policy = r
+			dialector.reader = true
+		}
+	}
+	for _, config := range pool.Writers {
+		if dialector, ok := config.Dialector.(*Dialector); ok {
+			dialector.policy = r
+		}
+	}
+}
+
+func (r *ReaderPolicy) addReader(pool gorm.ConnPool, fullConfig contracts.FullConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.readers[pool] = &readerNode{fullConfig: fullConfig, healthy: true}
+	if r.stop == nil && fullConfig.ReplicaCheck.Interval > 0 {
+		r.stop = make(chan struct{})
+		go r.run(fullConfig.ReplicaCheck.Interval, r.stop)
+	}
+}
+
+func (r *ReaderPolicy) addWriter(pool gorm.ConnPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.writer = pool
+}
+
+// route chooses the registered reader that a statement is sent to, fallback is returned if no reader is registered.
+func (r *ReaderPolicy) route(fallback gorm.ConnPool) gorm.ConnPool {
+	r.mu.RLock()
+	pools := make([]gorm.ConnPool, 0, len(r.readers))
+	for pool := range r.readers {
+		pools = append(pools, pool)
+	}
+	r.mu.RUnlock()
+
+	if len(pools) == 0 {
+		return fallback
+	}
+
+	return r.Resolve(pools)
+}
+
+func (r *ReaderPolicy) run(interval time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			r.check(interval)
+		}
+	}
+}
+
+// check refreshes the health of every reader, the state changes are logged.
+func (r *ReaderPolicy) check(timeout time.Duration) {
+	r.mu.RLock()
+	nodes := make(map[gorm.ConnPool]*readerNode, len(r.readers))
+	for pool, node := range r.readers {
+		nodes[pool] = node
+	}
+	r.mu.RUnlock()
+
+	for pool, node := range nodes {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		lag, err := r.lag(ctx, pool)
+		cancel()
+
+		if err == nil && node.fullConfig.ReplicaCheck.MaxLag > 0 && lag > node.fullConfig.ReplicaCheck.MaxLag {
+			err = ReplicaLagTooHigh.Args(lag, node.fullConfig.ReplicaCheck.MaxLag)
+		}
+
+		r.mu.Lock()
+		healthy := node.healthy
+		node.healthy = err == nil
+		r.mu.Unlock()
+
+		if r.log == nil {
+			continue
+		}
+		if healthy && err != nil {
+			r.log.Warningf("[%s] reader %s of connection %s is skipped: %v", Name, nodeAddress(node.fullConfig), node.fullConfig.Connection, err)
+		}
+		if !healthy && err == nil {
+			r.log.Infof("[%s] reader %s of connection %s is recovered", Name, nodeAddress(node.fullConfig), node.fullConfig.Connection)
+		}
+	}
+}
+
+// readerPool The connection pool of a reader that is handed to dbresolver, the statements are sent to the reader chosen
+// by the policy rather than the one chosen by dbresolver.
+type readerPool struct {
+	*sql.DB
+	policy *ReaderPolicy
+}
+
+func (r *readerPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	if beginner, ok := r.resolve().(gorm.TxBeginner); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+
+	return r.DB.BeginTx(ctx, opts)
+}
+
+func (r *readerPool) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return r.resolve().ExecContext(ctx, query, args...)
+}
+
+// GetDBConn returns the connection pool of the reader itself, the framework configures it via gorm.DB.DB.
+func (r *readerPool) GetDBConn() (*sql.DB, error) {
+	return r.DB, nil
+}
+
+func (r *readerPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return r.resolve().PrepareContext(ctx, query)
+}
+
+func (r *readerPool) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return r.resolve().QueryContext(ctx, query, args...)
+}
+
+func (r *readerPool) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return r.resolve().QueryRowContext(ctx, query, args...)
+}
+
+func (r *readerPool) resolve() gorm.ConnPool {
+	return r.policy.route(r.DB)
+}
+
+// replicaLag returns the lag of the replica, zero is returned if the node is not a replica.
+func replicaLag(ctx context.Context, pool gorm.ConnPool) (time.Duration, error) {
+	rows, err := pool.QueryContext(ctx, "SHOW REPLICA STATUS")
+	if err != nil {
+		// SHOW REPLICA STATUS is not supported by MySQL before 8.0.22 and MariaDB before 10.5.1.
+		rows, err = pool.QueryContext(ctx, "SHOW SLAVE STATUS")
+		if err != nil {
+			return 0, err
+		}
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	if !rows.Next() {
+		return 0, rows.Err()
+	}
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return 0, err
+	}
+
+	for i, column := range columns {
+		for _, lagColumn := range replicaLagColumns {
+			if column != lagColumn {
+				continue
+			}
+			// The lag is NULL when the replication threads are not running.
+			if !values[i].Valid {
+				return 0, ReplicationNotRunning
+			}
+
+			seconds, err := strconv.Atoi(values[i].String)
+			if err != nil {
+				return 0, err
+			}
+
+			return time.Duration(seconds) * time.Second, nil
+		}
+	}
+
+	return 0, nil
+}
+
+func nodeAddress(fullConfig contracts.FullConfig) string {
+	if fullConfig.Socket != "" {
+		return fullConfig.Socket
+	}
+
+	return net.JoinHostPort(fullConfig.Host, strconv.Itoa(fullConfig.Port))
+}
@@ -0,0 +1,191 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/contracts/database"
+	databasedriver "github.com/goravel/framework/database/driver"
+	mocksconfig "github.com/goravel/framework/mocks/config"
+	mockslog "github.com/goravel/framework/mocks/log"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/goravel/mysql/contracts"
+	mocks "github.com/goravel/mysql/mocks"
+)
+
+type testConnPool struct {
+	gorm.ConnPool
+	name string
+}
+
+func TestReaderPolicyResolve(t *testing.T) {
+	reader1 := &testConnPool{name: "reader1"}
+	reader2 := &testConnPool{name: "reader2"}
+	writer := &testConnPool{name: "writer"}
+
+	policy := NewReaderPolicy(nil)
+	policy.addReader(reader1, contracts.FullConfig{Config: contracts.Config{Weight: 1}})
+	policy.addReader(reader2, contracts.FullConfig{Config: contracts.Config{Weight: 99}})
+
+	counts := make(map[gorm.ConnPool]int)
+	for range 1000 {
+		counts[policy.Resolve([]gorm.ConnPool{reader1, reader2})]++
+	}
+	assert.Greater(t, counts[reader2], counts[reader1])
+	assert.Zero(t, counts[writer])
+
+	// The unhealthy reader is skipped.
+	policy.readers[reader2].healthy = false
+	for range 10 {
+		assert.Same(t, reader1, policy.Resolve([]gorm.ConnPool{reader1, reader2}))
+	}
+
+	// One of the readers is returned if no reader is available and the writer is unknown.
+	policy.readers[reader1].healthy = false
+	assert.Contains(t, []gorm.ConnPool{reader1, reader2}, policy.Resolve([]gorm.ConnPool{reader1, reader2}))
+
+	// Fall back to the writer if no reader is available.
+	policy.addWriter(writer)
+	assert.Same(t, writer, policy.Resolve([]gorm.ConnPool{reader1, reader2}))
+
+	// The connection pools that are not registered are treated as healthy.
+	unknown := &testConnPool{name: "unknown"}
+	assert.Same(t, unknown, policy.Resolve([]gorm.ConnPool{reader1, reader2, unknown}))
+}
+
+func TestReaderPolicyCheck(t *testing.T) {
+	reader := &testConnPool{name: "reader"}
+	mockLog := mockslog.NewLog(t)
+
+	var (
+		lag    time.Duration
+		lagErr error
+	)
+	policy := NewReaderPolicy(mockLog)
+	policy.lag = func(ctx context.Context, pool gorm.ConnPool) (time.Duration, error) {
+		assert.Same(t, reader, pool)
+
+		return lag, lagErr
+	}
+	policy.addReader(reader, contracts.FullConfig{
+		Config:       contracts.Config{Host: "127.0.0.1", Port: 3306},
+		Connection:   "mysql",
+		ReplicaCheck: contracts.ReplicaCheck{MaxLag: 10 * time.Second},
+	})
+
+	// The reader is healthy.
+	lag = 5 * time.Second
+	policy.check(time.Second)
+	assert.True(t, policy.readers[reader].healthy)
+
+	// The reader lags behind the source too much.
+	lag = 30 * time.Second
+	mockLog.EXPECT().Warningf("[%s] reader %s of connection %s is skipped: %v", Name, "127.0.0.1:3306", "mysql",
+		ReplicaLagTooHigh.Args(30*time.Second, 10*time.Second)).Once()
+	policy.check(time.Second)
+	assert.False(t, policy.readers[reader].healthy)
+
+	// The state isn't logged again if it's unchanged.
+	lag = 0
+	lagErr = ReplicationNotRunning
+	policy.check(time.Second)
+	assert.False(t, policy.readers[reader].healthy)
+
+	// The reader is recovered.
+	lagErr = nil
+	mockLog.EXPECT().Infof("[%s] reader %s of connection %s is recovered", Name, "127.0.0.1:3306", "mysql").Once()
+	policy.check(time.Second)
+	assert.True(t, policy.readers[reader].healthy)
+
+	// The reader is unreachable.
+	lagErr = errors.New("connection refused")
+	mockLog.EXPECT().Warningf("[%s] reader %s of connection %s is skipped: %v", Name, "127.0.0.1:3306", "mysql", lagErr).Once()
+	policy.check(time.Second)
+	assert.False(t, policy.readers[reader].healthy)
+
+	// The state changes aren't logged without a logger.
+	policy.log = nil
+	lagErr = nil
+	policy.check(time.Second)
+	assert.True(t, policy.readers[reader].healthy)
+}
+
+func TestReaderPolicyAttach(t *testing.T) {
+	policy := NewReaderPolicy(nil)
+	reader, err := NewDialector(contracts.FullConfig{Config: contracts.Config{Host: "localhost", Port: 3306}})
+	assert.NoError(t, err)
+	reader.SkipInitializeWithVersion = true
+	writer, err := NewDialector(contracts.FullConfig{Config: contracts.Config{Host: "localhost", Port: 3306}})
+	assert.NoError(t, err)
+	writer.SkipInitializeWithVersion = true
+
+	policy.attach(database.Pool{
+		Readers: []database.Config{{Dialector: reader}},
+		Writers: []database.Config{{Dialector: writer}},
+	})
+
+	_, err = gorm.Open(reader, &gorm.Config{DisableAutomaticPing: true})
+	assert.NoError(t, err)
+	_, err = gorm.Open(writer, &gorm.Config{DisableAutomaticPing: true})
+	assert.NoError(t, err)
+
+	assert.Contains(t, policy.readers, reader.Conn)
+	assert.Same(t, writer.Conn, policy.writer)
+	assert.Same(t, writer.Conn, policy.Resolve([]gorm.ConnPool{writer.Conn}))
+
+	// The reader handed to dbresolver routes the statements via the policy.
+	instance, err := gorm.Open(reader, &gorm.Config{DisableAutomaticPing: true})
+	assert.NoError(t, err)
+	assert.IsType(t, &readerPool{}, instance.ConnPool)
+	assert.Same(t, reader.Conn, instance.ConnPool.(*readerPool).resolve())
+}
+
+func TestReaderPolicyWithBuildGorm(t *testing.T) {
+	// The port is closed, so the statements fail with the address of the node they are sent to.
+	node := func(host string) contracts.FullConfig {
+		return contracts.FullConfig{
+			Connection: "policy",
+			Config:     contracts.Config{Host: host, Port: 1, Database: "goravel"},
+		}
+	}
+
+	mockConfigBuilder := mocks.NewConfigBuilder(t)
+	mockConfigBuilder.EXPECT().Readers().Return([]contracts.FullConfig{node("127.0.0.2"), node("127.0.0.3")}).Once()
+	mockConfigBuilder.EXPECT().Writers().Return([]contracts.FullConfig{node("127.0.0.1")}).Once()
+
+	policy := connectionReaderPolicy("policy", nil)
+	assert.Same(t, policy, connectionReaderPolicy("policy", nil))
+
+	pool := (&Mysql{config: mockConfigBuilder, policy: policy}).Pool()
+	for _, config := range append(pool.Writers, pool.Readers...) {
+		config.Dialector.(*Dialector).SkipInitializeWithVersion = true
+	}
+
+	mockConfig := mocksconfig.NewConfig(t)
+	mockConfig.EXPECT().GetInt("database.pool.max_idle_conns", 10).Return(10).Once()
+	mockConfig.EXPECT().GetInt("database.pool.max_open_conns", 100).Return(100).Once()
+	mockConfig.EXPECT().GetDuration("database.pool.conn_max_idletime", time.Duration(3600)).Return(3600).Once()
+	mockConfig.EXPECT().GetDuration("database.pool.conn_max_lifetime", time.Duration(3600)).Return(3600).Once()
+
+	instance, _, err := databasedriver.BuildGorm(mockConfig, logger.Discard, pool, "policy", nil)
+	assert.NoError(t, err)
+
+	// The unhealthy reader is skipped though dbresolver is registered with a random policy by the framework.
+	policy.readers[pool.Readers[1].Dialector.(*Dialector).Conn].healthy = false
+	for range 20 {
+		var value int
+		err := instance.Clauses(dbresolver.Read).Raw("SELECT 1").Scan(&value).Error
+		assert.ErrorContains(t, err, "127.0.0.2:1")
+	}
+
+	// The policy is closed together with the writer.
+	databasedriver.CloseConnections()
+	assert.Empty(t, policy.readers)
+	assert.NotSame(t, policy, connectionReaderPolicy("policy", nil))
+}
@@ -4,10 +4,19 @@ import (
 	"strings"
 
 	"github.com/goravel/framework/contracts/database/driver"
+
+	"github.com/goravel/mysql/contracts"
 )
 
 var _ driver.Processor = &Processor{}
 
+// generatedColumns The Extra of the generated columns and their kinds, MariaDB calls a stored column persistent.
+var generatedColumns = map[string]string{
+	"persistent generated": "stored",
+	"stored generated":     "stored",
+	"virtual generated":    "virtual",
+}
+
 type Processor struct {
 }
 
@@ -15,6 +24,20 @@ func NewProcessor() *Processor {
 	return &Processor{}
 }
 
+// ProcessCheckConstraints removes the parentheses that MySQL wraps the expression with, so it's the same as the
+// expression that is passed to CompileCheck.
+func (r Processor) ProcessCheckConstraints(dbCheckConstraints []contracts.DBCheckConstraint) []contracts.CheckConstraint {
+	var checkConstraints []contracts.CheckConstraint
+	for _, dbCheckConstraint := range dbCheckConstraints {
+		checkConstraints = append(checkConstraints, contracts.CheckConstraint{
+			Name:       dbCheckConstraint.Name,
+			Expression: trimOuterParentheses(dbCheckConstraint.Expression),
+		})
+	}
+
+	return checkConstraints
+}
+
 func (r Processor) ProcessColumns(dbColumns []driver.DBColumn) []driver.Column {
 	var columns []driver.Column
 	for _, dbColumn := range dbColumns {
@@ -26,13 +49,18 @@ func (r Processor) ProcessColumns(dbColumns []driver.DBColumn) []driver.Column {
 		if dbColumn.Extra == "auto_increment" {
 			autoIncrement = true
 		}
+		// The generated columns are marked by Extra: "virtual generated" or "stored generated".
+		extra := dbColumn.Extra
+		if generated, ok := generatedColumns[strings.ToLower(extra)]; ok {
+			extra = generated + " generated"
+		}
 
 		columns = append(columns, driver.Column{
 			Autoincrement: autoIncrement,
 			Collation:     dbColumn.Collation,
 			Comment:       dbColumn.Comment,
 			Default:       dbColumn.Default,
-			Extra:         dbColumn.Extra,
+			Extra:         extra,
 			Name:          dbColumn.Name,
 			Nullable:      nullable,
 			Type:          dbColumn.Type,
@@ -76,6 +104,60 @@ func (r Processor) ProcessIndexes(dbIndexes []driver.DBIndex) []driver.Index {
 	return indexes
 }
 
+func (r Processor) ProcessIndexParts(dbIndexParts []contracts.DBIndexPart) []contracts.IndexPart {
+	var indexParts []contracts.IndexPart
+	for _, dbIndexPart := range dbIndexParts {
+		indexParts = append(indexParts, contracts.IndexPart{
+			Index:      strings.ToLower(dbIndexPart.Index),
+			Column:     dbIndexPart.Column,
+			Expression: dbIndexPart.Expression,
+			Length:     dbIndexPart.Length,
+			Desc:       dbIndexPart.Desc,
+			Position:   dbIndexPart.Position,
+		})
+	}
+
+	return indexParts
+}
+
+func (r Processor) ProcessPartitions(dbPartitions []contracts.DBPartition) []contracts.Partition {
+	var partitions []contracts.Partition
+	for _, dbPartition := range dbPartitions {
+		partitions = append(partitions, contracts.Partition{
+			Name:        dbPartition.Name,
+			Method:      strings.ToLower(dbPartition.Method),
+			Expression:  dbPartition.Expression,
+			Description: dbPartition.Description,
+			Position:    dbPartition.Position,
+			Rows:        dbPartition.Rows,
+		})
+	}
+
+	return partitions
+}
+
 func (r Processor) ProcessTypes(types []driver.Type) []driver.Type {
 	return types
 }
+
+// trimOuterParentheses removes a pair of parentheses that wraps the whole expression, "(a > 0) and (b > 0)" is kept.
+func trimOuterParentheses(expression string) string {
+	if !strings.HasPrefix(expression, "(") || !strings.HasSuffix(expression, ")") {
+		return expression
+	}
+
+	depth := 0
+	for i, char := range expression {
+		switch char {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && i != len(expression)-1 {
+				return expression
+			}
+		}
+	}
+
+	return expression[1 : len(expression)-1]
+}
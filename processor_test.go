@@ -5,6 +5,8 @@ import (
 
 	"github.com/goravel/framework/contracts/database/driver"
 	"github.com/stretchr/testify/suite"
+
+	"github.com/goravel/mysql/contracts"
 )
 
 type ProcessorTestSuite struct {
@@ -37,6 +39,17 @@ func (s *ProcessorTestSuite) TestProcessColumns() {
 				{Autoincrement: false, Collation: "utf8_general_ci", Comment: "user name", Default: "", Name: "name", Nullable: true, Type: "varchar", TypeName: "VARCHAR"},
 			},
 		},
+		{
+			name: "GeneratedColumn",
+			dbColumns: []driver.DBColumn{
+				{Name: "total", Type: "int", TypeName: "int", Nullable: "YES", Extra: "VIRTUAL GENERATED", Default: "(`price` * `quantity`)"},
+				{Name: "amount", Type: "int", TypeName: "int", Nullable: "YES", Extra: "PERSISTENT GENERATED", Default: "`price` * `quantity`"},
+			},
+			expected: []driver.Column{
+				{Default: "(`price` * `quantity`)", Extra: "virtual generated", Name: "total", Nullable: true, Type: "int", TypeName: "int"},
+				{Default: "`price` * `quantity`", Extra: "stored generated", Name: "amount", Nullable: true, Type: "int", TypeName: "int"},
+			},
+		},
 		{
 			name:      "EmptyInput",
 			dbColumns: []driver.DBColumn{},
@@ -130,3 +143,48 @@ func (s *ProcessorTestSuite) TestProcessIndexes() {
 		})
 	}
 }
+
+func (s *ProcessorTestSuite) TestProcessIndexParts() {
+	dbIndexParts := []contracts.DBIndexPart{
+		{Index: "PRIMARY", Column: "id", Position: 1},
+		{Index: "users_email_lower_index", Expression: "concat(lower(`email`),_utf8mb4',(')", Position: 1},
+		{Index: "users_name_created_at_index", Column: "name", Length: 50, Position: 1},
+		{Index: "users_name_created_at_index", Column: "created_at", Desc: true, Position: 2},
+	}
+
+	s.Equal([]contracts.IndexPart{
+		{Index: "primary", Column: "id", Position: 1},
+		{Index: "users_email_lower_index", Expression: "concat(lower(`email`),_utf8mb4',(')", Position: 1},
+		{Index: "users_name_created_at_index", Column: "name", Length: 50, Position: 1},
+		{Index: "users_name_created_at_index", Column: "created_at", Desc: true, Position: 2},
+	}, s.processor.ProcessIndexParts(dbIndexParts))
+	s.Nil(s.processor.ProcessIndexParts(nil))
+}
+
+func (s *ProcessorTestSuite) TestProcessCheckConstraints() {
+	dbCheckConstraints := []contracts.DBCheckConstraint{
+		{Name: "users_age_check", Expression: "(`age` >= 18)"},
+		{Name: "users_range_check", Expression: "(`min` > 0) and (`max` > `min`)"},
+		{Name: "users_status_check", Expression: "`status` in (_utf8mb4'active',_utf8mb4'inactive')"},
+	}
+
+	s.Equal([]contracts.CheckConstraint{
+		{Name: "users_age_check", Expression: "`age` >= 18"},
+		{Name: "users_range_check", Expression: "(`min` > 0) and (`max` > `min`)"},
+		{Name: "users_status_check", Expression: "`status` in (_utf8mb4'active',_utf8mb4'inactive')"},
+	}, s.processor.ProcessCheckConstraints(dbCheckConstraints))
+	s.Nil(s.processor.ProcessCheckConstraints(nil))
+}
+
+func (s *ProcessorTestSuite) TestProcessPartitions() {
+	dbPartitions := []contracts.DBPartition{
+		{Name: "p2025", Method: "RANGE", Expression: "year(`created_at`)", Description: "2026", Position: 1, Rows: 10},
+		{Name: "pmax", Method: "RANGE", Expression: "year(`created_at`)", Description: "MAXVALUE", Position: 2},
+	}
+
+	s.Equal([]contracts.Partition{
+		{Name: "p2025", Method: "range", Expression: "year(`created_at`)", Description: "2026", Position: 1, Rows: 10},
+		{Name: "pmax", Method: "range", Expression: "year(`created_at`)", Description: "MAXVALUE", Position: 2},
+	}, s.processor.ProcessPartitions(dbPartitions))
+	s.Nil(s.processor.ProcessPartitions(nil))
+}
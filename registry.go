@@ -0,0 +1,80 @@
+package mysql
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+	"weak"
+)
+
+// registry The MySQL attributes of the values of the framework that can't carry them, e.g. the blueprints and their
+// columns. The owners are referenced weakly, so the attributes are the same every time the owner is compiled, and they
+// are released by the garbage collector along with the owner, the attributes can't reference the owner.
+type registry[V any] struct {
+	mu      sync.RWMutex
+	entries map[weak.Pointer[byte]]*V
+}
+
+func newRegistry[V any]() *registry[V] {
+	return &registry[V]{entries: make(map[weak.Pointer[byte]]*V)}
+}
+
+// get returns the attributes of the owner, nil if they aren't set.
+func (r *registry[V]) get(owner any) *V {
+	pointer := registryPointer(owner)
+	if pointer == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.entries[weak.Make(pointer)]
+}
+
+// getOrCreate returns the attributes of the owner, they are created if they aren't set.
+func (r *registry[V]) getOrCreate(owner any) *V {
+	pointer := registryPointer(owner)
+	if pointer == nil {
+		return new(V)
+	}
+
+	key := weak.Make(pointer)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if attributes, ok := r.entries[key]; ok {
+		return attributes
+	}
+
+	attributes := new(V)
+	r.entries[key] = attributes
+	runtime.AddCleanup(pointer, r.delete, key)
+
+	return attributes
+}
+
+func (r *registry[V]) delete(key weak.Pointer[byte]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.entries, key)
+}
+
+func (r *registry[V]) len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.entries)
+}
+
+// registryPointer returns the address of the owner, the owners are implemented by pointers, nil is returned if it isn't.
+func registryPointer(owner any) *byte {
+	value := reflect.ValueOf(owner)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return nil
+	}
+
+	return (*byte)(value.UnsafePointer())
+}
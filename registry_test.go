@@ -0,0 +1,32 @@
+package mysql
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/database/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := newRegistry[columnAttributes]()
+	column := schema.NewColumnDefinition("token", "string")
+
+	assert.Nil(t, registry.get(column))
+	attributes := registry.getOrCreate(column)
+	assert.Same(t, attributes, registry.getOrCreate(column))
+	assert.Same(t, attributes, registry.get(column))
+	assert.Nil(t, registry.get(schema.NewColumnDefinition("token", "string")))
+	assert.Nil(t, registry.get(nil))
+	assert.NotNil(t, registry.getOrCreate(nil))
+	assert.Equal(t, 1, registry.len())
+
+	// The attributes are released along with the owner.
+	runtime.KeepAlive(column)
+	assert.Eventually(t, func() bool {
+		runtime.GC()
+
+		return registry.len() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,113 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/goravel/framework/contracts/log"
+	"gorm.io/gorm"
+
+	"github.com/goravel/mysql/contracts"
+)
+
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryBackoff     = 50 * time.Millisecond
+)
+
+// defaultRetryCodes ER_LOCK_DEADLOCK and ER_LOCK_WAIT_TIMEOUT, the server rolls back the statement or the whole
+// transaction, so it's safe to run the transaction again.
+var defaultRetryCodes = []uint16{1213, 1205}
+
+type retryKey struct{}
+
+type nonIdempotentKey struct{}
+
+// NonIdempotent marks the closure run with the context as non-idempotent, it's never retried by Retrier.
+func NonIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, nonIdempotentKey{}, true)
+}
+
+// Retrier runs a transaction closure again when the server returns a retryable error, e.g. a deadlock.
+type Retrier struct {
+	log   log.Log
+	retry contracts.Retry
+}
+
+func NewRetrier(retry contracts.Retry, log log.Log) *Retrier {
+	if retry.MaxAttempts <= 0 {
+		retry.MaxAttempts = defaultRetryMaxAttempts
+	}
+	if retry.Backoff <= 0 {
+		retry.Backoff = defaultRetryBackoff
+	}
+	if len(retry.Codes) == 0 {
+		retry.Codes = defaultRetryCodes
+	}
+
+	return &Retrier{
+		log:   log,
+		retry: retry,
+	}
+}
+
+// Run runs the closure, it's run again with a jittered backoff when it returns a retryable error. The closure is run
+// only once if it's marked by NonIdempotent or it's nested in another Run, the outer one retries the whole closure.
+func (r *Retrier) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(retryKey{}) != nil || ctx.Value(nonIdempotentKey{}) != nil {
+		return fn(ctx)
+	}
+
+	ctx = context.WithValue(ctx, retryKey{}, true)
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || attempt >= r.retry.MaxAttempts || !r.retryable(err) {
+			return err
+		}
+
+		backoff := r.backoff(attempt)
+		if r.log != nil {
+			r.log.Warningf("[%s] retry the transaction in %s, attempt %d of %d: %v", Name, backoff, attempt+1, r.retry.MaxAttempts, err)
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// Transaction runs the closure in a transaction via Run. The closure isn't retried if the db is in a transaction
+// already, the nested transaction is a savepoint that can't be run again alone.
+func (r *Retrier) Transaction(ctx context.Context, db *gorm.DB, fc func(tx *gorm.DB) error) error {
+	if _, ok := db.Statement.ConnPool.(gorm.TxCommitter); ok {
+		return db.WithContext(ctx).Transaction(fc)
+	}
+
+	return r.Run(ctx, func(ctx context.Context) error {
+		return db.WithContext(ctx).Transaction(fc)
+	})
+}
+
+// backoff doubles the base backoff after each attempt, and picks a random duration in its upper half.
+func (r *Retrier) backoff(attempt int) time.Duration {
+	backoff := r.retry.Backoff << min(attempt-1, 10)
+
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+func (r *Retrier) retryable(err error) bool {
+	var mysqlError *gomysql.MySQLError
+	if !errors.As(err, &mysqlError) {
+		return false
+	}
+
+	return slices.Contains(r.retry.Codes, mysqlError.Number)
+}
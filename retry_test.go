@@ -0,0 +1,148 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gomysql "github.com/go-sql-driver/mysql"
+	mockslog "github.com/goravel/framework/mocks/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/goravel/mysql/contracts"
+)
+
+func TestRetrierRun(t *testing.T) {
+	deadlock := &gomysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock; try restarting transaction"}
+	duplicate := &gomysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 'PRIMARY'"}
+
+	tests := []struct {
+		name          string
+		ctx           func() context.Context
+		errs          []error
+		expectErr     error
+		expectRuns    int
+		expectRetries int
+	}{
+		{
+			name:       "success",
+			errs:       []error{nil},
+			expectRuns: 1,
+		},
+		{
+			name:          "success after retrying",
+			errs:          []error{deadlock, deadlock, nil},
+			expectRuns:    3,
+			expectRetries: 2,
+		},
+		{
+			name:          "exceed the max attempts",
+			errs:          []error{deadlock, deadlock, deadlock, nil},
+			expectErr:     deadlock,
+			expectRuns:    3,
+			expectRetries: 2,
+		},
+		{
+			name:       "not retryable",
+			errs:       []error{duplicate, nil},
+			expectErr:  duplicate,
+			expectRuns: 1,
+		},
+		{
+			name: "non-idempotent",
+			ctx: func() context.Context {
+				return NonIdempotent(context.Background())
+			},
+			errs:       []error{deadlock, nil},
+			expectErr:  deadlock,
+			expectRuns: 1,
+		},
+		{
+			name: "context is canceled",
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				return ctx
+			},
+			errs:          []error{deadlock, nil},
+			expectErr:     deadlock,
+			expectRuns:    1,
+			expectRetries: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockLog := mockslog.NewLog(t)
+			if test.expectRetries > 0 {
+				mockLog.EXPECT().Warningf("[%s] retry the transaction in %s, attempt %d of %d: %v",
+					Name, mock.Anything, mock.Anything, 3, deadlock).Times(test.expectRetries)
+			}
+
+			ctx := context.Background()
+			if test.ctx != nil {
+				ctx = test.ctx()
+			}
+
+			runs := 0
+			retrier := NewRetrier(contracts.Retry{Backoff: time.Millisecond}, mockLog)
+			err := retrier.Run(ctx, func(ctx context.Context) error {
+				err := test.errs[runs]
+				runs++
+
+				return err
+			})
+
+			assert.Equal(t, test.expectErr, err)
+			assert.Equal(t, test.expectRuns, runs)
+		})
+	}
+}
+
+func TestRetrierRunNested(t *testing.T) {
+	deadlock := &gomysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock; try restarting transaction"}
+	mockLog := mockslog.NewLog(t)
+	mockLog.EXPECT().Warningf("[%s] retry the transaction in %s, attempt %d of %d: %v",
+		Name, mock.Anything, 2, 2, deadlock).Once()
+
+	retrier := NewRetrier(contracts.Retry{MaxAttempts: 2, Backoff: time.Millisecond}, mockLog)
+	outerRuns, innerRuns := 0, 0
+	err := retrier.Run(context.Background(), func(ctx context.Context) error {
+		outerRuns++
+
+		// The nested closure isn't retried, the outer one is retried instead.
+		return retrier.Run(ctx, func(ctx context.Context) error {
+			innerRuns++
+
+			return deadlock
+		})
+	})
+
+	assert.Equal(t, deadlock, err)
+	assert.Equal(t, 2, outerRuns)
+	assert.Equal(t, 2, innerRuns)
+}
+
+func TestRetrierRetryable(t *testing.T) {
+	retrier := NewRetrier(contracts.Retry{Codes: []uint16{1205}}, nil)
+
+	assert.True(t, retrier.retryable(&gomysql.MySQLError{Number: 1205}))
+	assert.False(t, retrier.retryable(&gomysql.MySQLError{Number: 1213}))
+	assert.False(t, retrier.retryable(errors.New("error")))
+	assert.True(t, NewRetrier(contracts.Retry{}, nil).retryable(&gomysql.MySQLError{Number: 1213}))
+}
+
+func TestRetrierBackoff(t *testing.T) {
+	retrier := NewRetrier(contracts.Retry{Backoff: 100 * time.Millisecond}, nil)
+
+	for attempt, expect := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond} {
+		for range 10 {
+			backoff := retrier.backoff(attempt + 1)
+			assert.GreaterOrEqual(t, backoff, expect/2)
+			assert.LessOrEqual(t, backoff, expect)
+		}
+	}
+}
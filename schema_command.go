@@ -0,0 +1,155 @@
+package mysql
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/goravel/framework/contracts/config"
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/contracts/log"
+	"github.com/goravel/framework/contracts/process"
+	"github.com/goravel/framework/support/path"
+)
+
+type SchemaDumpCommand struct {
+	config  config.Config
+	log     log.Log
+	process process.Process
+}
+
+func NewSchemaDumpCommand(config config.Config, log log.Log, process process.Process) *SchemaDumpCommand {
+	return &SchemaDumpCommand{
+		config:  config,
+		log:     log,
+		process: process,
+	}
+}
+
+// Signature The name and signature of the console command.
+func (r *SchemaDumpCommand) Signature() string {
+	return "mysql:schema-dump"
+}
+
+// Description The console command description.
+func (r *SchemaDumpCommand) Description() string {
+	return "Dump the schema of the MySQL database"
+}
+
+// Extend The console command extend.
+func (r *SchemaDumpCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "mysql",
+		Flags:    schemaFlags("the path of the dump file, default is database/schema/{connection}-schema.sql"),
+	}
+}
+
+// Handle Execute the console command.
+func (r *SchemaDumpCommand) Handle(ctx console.Context) error {
+	connection := schemaConnection(ctx, r.config)
+	dumpPath := schemaPath(ctx, connection)
+	if err := os.MkdirAll(filepath.Dir(dumpPath), os.ModePerm); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	mysql := NewMysql(r.config, r.log, r.process, connection)
+	dump := mysql.SchemaDump
+	if ctx.OptionBool("native") {
+		dump = mysql.SchemaDumpNative
+	}
+	if err := dump(dumpPath); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success("Schema dumped to " + dumpPath)
+
+	return nil
+}
+
+type SchemaLoadCommand struct {
+	config  config.Config
+	log     log.Log
+	process process.Process
+}
+
+func NewSchemaLoadCommand(config config.Config, log log.Log, process process.Process) *SchemaLoadCommand {
+	return &SchemaLoadCommand{
+		config:  config,
+		log:     log,
+		process: process,
+	}
+}
+
+// Signature The name and signature of the console command.
+func (r *SchemaLoadCommand) Signature() string {
+	return "mysql:schema-load"
+}
+
+// Description The console command description.
+func (r *SchemaLoadCommand) Description() string {
+	return "Load the schema dump into the MySQL database"
+}
+
+// Extend The console command extend.
+func (r *SchemaLoadCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "mysql",
+		Flags:    schemaFlags("the path of the dump file to load, default is database/schema/{connection}-schema.sql"),
+	}
+}
+
+// Handle Execute the console command.
+func (r *SchemaLoadCommand) Handle(ctx console.Context) error {
+	connection := schemaConnection(ctx, r.config)
+	dumpPath := schemaPath(ctx, connection)
+	mysql := NewMysql(r.config, r.log, r.process, connection)
+	load := mysql.SchemaLoad
+	if ctx.OptionBool("native") {
+		load = mysql.SchemaLoadNative
+	}
+	if err := load(dumpPath); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success("Schema loaded from " + dumpPath)
+
+	return nil
+}
+
+func schemaFlags(pathUsage string) []command.Flag {
+	return []command.Flag{
+		&command.StringFlag{
+			Name:    "connection",
+			Aliases: []string{"c"},
+			Usage:   "the database connection, default is database.default",
+		},
+		&command.StringFlag{
+			Name:    "path",
+			Aliases: []string{"p"},
+			Usage:   pathUsage,
+		},
+		&command.BoolFlag{
+			Name:  "native",
+			Usage: "use the built-in dumper instead of mysqldump and mysql, the native dump can only be loaded natively",
+		},
+	}
+}
+
+func schemaConnection(ctx console.Context, config config.Config) string {
+	if connection := ctx.Option("connection"); connection != "" {
+		return connection
+	}
+
+	return config.GetString("database.default")
+}
+
+func schemaPath(ctx console.Context, connection string) string {
+	if dumpPath := ctx.Option("path"); dumpPath != "" {
+		return dumpPath
+	}
+
+	return path.Database("schema", connection+"-schema.sql")
+}
@@ -0,0 +1,289 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/goravel/framework/errors"
+	"gorm.io/gorm"
+)
+
+var (
+	// autoIncrementRegex The AUTO_INCREMENT table option, it changes with the data, so it's stripped.
+	autoIncrementRegex = regexp.MustCompile(` AUTO_INCREMENT=\d+`)
+	// definerRegex The DEFINER clause of the views, routines and triggers, it changes with the dumping user.
+	definerRegex = regexp.MustCompile("DEFINER=`(?:[^`]|``)*`@`(?:[^`]|``)*` ?")
+)
+
+// SchemaDump dumps the structure of the database and the rows of the migrations table to the path via mysqldump,
+// the AUTO_INCREMENT values and the definers are stripped, so the dump is stable.
+func (r *Mysql) SchemaDump(path string) error {
+	if r.process == nil {
+		return errors.ProcessFacadeNotSet.SetModule(Name)
+	}
+
+	optionFile, database, err := r.clientOptionFile()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(optionFile)
+	}()
+
+	args := []string{
+		"--defaults-extra-file=" + optionFile,
+		"--no-data", "--routines", "--single-transaction", "--no-tablespaces",
+		"--skip-add-locks", "--skip-comments", "--skip-set-charset",
+		"--result-file=" + path,
+	}
+	if _, name := r.versionAndName(); name != "MariaDB" {
+		args = append(args, "--set-gtid-purged=OFF")
+	}
+	if result := r.process.Run("mysqldump", append(args, database)...); result.Failed() {
+		return FailedToDumpSchema.Args(result.ErrorOutput())
+	}
+
+	migrations, err := os.CreateTemp("", "goravel-migrations-*.sql")
+	if err != nil {
+		return err
+	}
+	_ = migrations.Close()
+	defer func() {
+		_ = os.Remove(migrations.Name())
+	}()
+
+	if result := r.process.Run("mysqldump",
+		"--defaults-extra-file="+optionFile,
+		"--no-create-info", "--skip-extended-insert", "--skip-routines", "--skip-triggers", "--compact", "--complete-insert",
+		"--result-file="+migrations.Name(),
+		database, r.migrationsTable(),
+	); result.Failed() {
+		return FailedToDumpSchema.Args(result.ErrorOutput())
+	}
+
+	structure, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	rows, err := os.ReadFile(migrations.Name())
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(normalizeSchemaDump(string(structure))+string(rows)), 0644)
+}
+
+// SchemaLoad loads the dump that is created by SchemaDump via the mysql client.
+func (r *Mysql) SchemaLoad(path string) error {
+	if r.process == nil {
+		return errors.ProcessFacadeNotSet.SetModule(Name)
+	}
+
+	optionFile, database, err := r.clientOptionFile()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(optionFile)
+	}()
+
+	if result := r.process.Run("mysql", "--defaults-extra-file="+optionFile, database, "--execute=source "+path); result.Failed() {
+		return FailedToLoadSchema.Args(path, result.ErrorOutput())
+	}
+
+	return nil
+}
+
+// clientOptionFile writes the connection options of the writer to a temporary option file of the MySQL clients,
+// so the password doesn't appear in the process list. The caller should remove the file.
+func (r *Mysql) clientOptionFile() (string, string, error) {
+	writers := r.config.Writers()
+	if len(writers) == 0 {
+		return "", "", errors.DatabaseConfigNotFound
+	}
+
+	config, err := dsnConfig(writers[0])
+	if err != nil {
+		return "", "", err
+	}
+	if config == nil {
+		return "", "", ConfigNotFound
+	}
+
+	options := []string{
+		"[client]",
+		"user=" + quoteOption(config.User),
+		"password=" + quoteOption(config.Passwd),
+	}
+	if config.Net == "unix" {
+		options = append(options, "socket="+quoteOption(config.Addr))
+	} else {
+		host, port, err := net.SplitHostPort(config.Addr)
+		if err != nil {
+			return "", "", err
+		}
+		options = append(options, "host="+quoteOption(host), "port="+port)
+	}
+	if writers[0].Sslmode != "" {
+		_, name := r.versionAndName()
+		options = append(options, sslModeOptions(writers[0].Sslmode, name)...)
+	}
+	if writers[0].SslCa != "" {
+		options = append(options, "ssl-ca="+quoteOption(writers[0].SslCa))
+	}
+	if writers[0].SslCert != "" {
+		options = append(options, "ssl-cert="+quoteOption(writers[0].SslCert))
+	}
+	if writers[0].SslKey != "" {
+		options = append(options, "ssl-key="+quoteOption(writers[0].SslKey))
+	}
+
+	file, err := os.CreateTemp("", "goravel-mysql-*.cnf")
+	if err != nil {
+		return "", "", err
+	}
+	if _, err := file.WriteString(strings.Join(options, "\n") + "\n"); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+
+		return "", "", err
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(file.Name())
+
+		return "", "", err
+	}
+
+	return file.Name(), config.DBName, nil
+}
+
+// sslModeOptions returns the client options of the sslmode, preferred is the default of the clients. The MariaDB
+// clients don't support ssl-mode, they verify both the certificate and the host name via ssl-verify-server-cert, so
+// verify-ca is verified as verify-identity.
+func sslModeOptions(sslmode, name string) []string {
+	if name != Name {
+		switch sslmode {
+		case SslmodeDisabled:
+			return []string{"skip-ssl"}
+		case SslmodeRequired:
+			return []string{"ssl", "disable-ssl-verify-server-cert"}
+		case SslmodeVerifyCa, SslmodeVerifyIdentity:
+			return []string{"ssl", "ssl-verify-server-cert"}
+		}
+
+		return nil
+	}
+
+	return []string{"ssl-mode=" + strings.ToUpper(strings.ReplaceAll(sslmode, "-", "_"))}
+}
+
+// SchemaDumpNative dumps the schema via SchemaDumper, it's the alternative of SchemaDump when mysqldump isn't
+// installed.
+func (r *Mysql) SchemaDumpNative(path string) error {
+	db, database, err := r.openWriter(nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	version, name := r.versionAndName()
+	grammar := NewGrammar(database, "", version, name)
+	if name != Name {
+		grammar = NewMariadbGrammar(database, "", version).Grammar
+	}
+	dump, err := NewSchemaDumper(db, grammar, database, r.migrationsTable()).Dump(context.Background())
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(dump), 0644)
+}
+
+// SchemaLoadNative executes the dump that is created by SchemaDumpNative via multiStatements.
+func (r *Mysql) SchemaLoadNative(path string) error {
+	dump, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	db, _, err := r.openWriter(map[string]string{"multiStatements": "true"})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	if _, err := db.Exec(string(dump)); err != nil {
+		return FailedToLoadSchema.Args(path, err)
+	}
+
+	return nil
+}
+
+// openWriter opens a standalone connection pool to the first writer, the params override the DSN parameters.
+// The caller should close it.
+func (r *Mysql) openWriter(params map[string]string) (*sql.DB, string, error) {
+	writers := r.config.Writers()
+	if len(writers) == 0 {
+		return nil, "", errors.DatabaseConfigNotFound
+	}
+
+	writer := writers[0]
+	if len(params) > 0 {
+		mergedParams := make(map[string]string, len(writer.Params)+len(params))
+		for key, value := range writer.Params {
+			mergedParams[key] = value
+		}
+		for key, value := range params {
+			mergedParams[key] = value
+		}
+		writer.Params = mergedParams
+	}
+
+	dialector, err := NewDialector(writer)
+	if err != nil {
+		return nil, "", err
+	}
+	if dialector == nil {
+		return nil, "", ConfigNotFound
+	}
+
+	instance, err := gorm.Open(dialector, &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		return nil, "", err
+	}
+	db, err := instance.DB()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return db, dialector.DSNConfig.DBName, nil
+}
+
+func (r *Mysql) migrationsTable() string {
+	table := r.config.Config().GetString("database.migrations.table", "migrations")
+	if writers := r.config.Writers(); len(writers) > 0 {
+		table = writers[0].Prefix + table
+	}
+
+	return table
+}
+
+func normalizeSchemaDump(dump string) string {
+	dump = autoIncrementRegex.ReplaceAllString(dump, "")
+
+	return definerRegex.ReplaceAllString(dump, "")
+}
+
+// quoteOption quotes the value of an option file, '\' and '"' are escaped.
+func quoteOption(value string) string {
+	return fmt.Sprintf(`"%s"`, strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value))
+}
@@ -0,0 +1,190 @@
+package mysql
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	contractsprocess "github.com/goravel/framework/contracts/process"
+	"github.com/goravel/framework/errors"
+	mocksconfig "github.com/goravel/framework/mocks/config"
+	"github.com/goravel/framework/process"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goravel/mysql/contracts"
+	mocks "github.com/goravel/mysql/mocks"
+)
+
+// testProcess records the commands and runs the handler instead of them.
+type testProcess struct {
+	contractsprocess.Process
+	commands [][]string
+	handler  func(name string, args []string) contractsprocess.Result
+}
+
+func (r *testProcess) Run(name string, args ...string) contractsprocess.Result {
+	r.commands = append(r.commands, append([]string{name}, args...))
+
+	return r.handler(name, args)
+}
+
+func argValue(args []string, prefix string) string {
+	for _, arg := range args {
+		if value, ok := strings.CutPrefix(arg, prefix); ok {
+			return value
+		}
+	}
+
+	return ""
+}
+
+func TestSchemaDump(t *testing.T) {
+	dumpPath := filepath.Join(t.TempDir(), "mysql-schema.sql")
+	structure := "CREATE TABLE `users` (\n  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB AUTO_INCREMENT=42 DEFAULT CHARSET=utf8mb4;\n" +
+		"CREATE DEFINER=`root`@`%` TRIGGER `users_bi` BEFORE INSERT ON `users` FOR EACH ROW SET NEW.id = NEW.id;\n" +
+		"/*!50013 DEFINER=`goravel`@`localhost` SQL SECURITY DEFINER */\n"
+	rows := "INSERT INTO `goravel_migrations` (`id`, `migration`, `batch`) VALUES (1,'20240101000000_create_users_table',1);\n"
+
+	var optionFile string
+	testProcess := &testProcess{
+		handler: func(name string, args []string) contractsprocess.Result {
+			assert.Equal(t, "mysqldump", name)
+
+			optionFile = argValue(args, "--defaults-extra-file=")
+			content, err := os.ReadFile(optionFile)
+			assert.NoError(t, err)
+			assert.Equal(t, "[client]\nuser=\"root\"\npassword=\"pass\\\"word\"\nhost=\"127.0.0.1\"\nport=3306\n", string(content))
+
+			resultFile := argValue(args, "--result-file=")
+			if strings.Contains(resultFile, "goravel-migrations") {
+				assert.NoError(t, os.WriteFile(resultFile, []byte(rows), 0644))
+			} else {
+				assert.NoError(t, os.WriteFile(resultFile, []byte(structure), 0644))
+			}
+
+			return process.NewResult(nil, 0, name, "", "")
+		},
+	}
+
+	mockConfig := mocksconfig.NewConfig(t)
+	mockConfig.EXPECT().GetString("database.migrations.table", "migrations").Return("migrations").Once()
+	mockConfigBuilder := mocks.NewConfigBuilder(t)
+	mockConfigBuilder.EXPECT().Config().Return(mockConfig).Once()
+	mockConfigBuilder.EXPECT().Writers().Return([]contracts.FullConfig{
+		{
+			Config: contracts.Config{
+				Host:     "127.0.0.1",
+				Port:     3306,
+				Database: "goravel",
+				Username: "root",
+				Password: `pass"word`,
+			},
+			Prefix: "goravel_",
+		},
+	})
+
+	mysql := &Mysql{
+		config:  mockConfigBuilder,
+		process: testProcess,
+		version: "8.0.36",
+	}
+	assert.NoError(t, mysql.SchemaDump(dumpPath))
+
+	content, err := os.ReadFile(dumpPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE `users` (\n  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n"+
+		"CREATE TRIGGER `users_bi` BEFORE INSERT ON `users` FOR EACH ROW SET NEW.id = NEW.id;\n"+
+		"/*!50013 SQL SECURITY DEFINER */\n"+rows, string(content))
+
+	assert.Len(t, testProcess.commands, 2)
+	assert.Contains(t, testProcess.commands[0], "--no-data")
+	assert.Contains(t, testProcess.commands[0], "--set-gtid-purged=OFF")
+	assert.Equal(t, "goravel", testProcess.commands[0][len(testProcess.commands[0])-1])
+	assert.Equal(t, []string{"goravel", "goravel_migrations"}, testProcess.commands[1][len(testProcess.commands[1])-2:])
+
+	// The option file is removed after dumping.
+	assert.NoFileExists(t, optionFile)
+}
+
+func TestSchemaDumpFailed(t *testing.T) {
+	sslCert := createTestKeyPair(t)
+	mockConfigBuilder := mocks.NewConfigBuilder(t)
+	mockConfigBuilder.EXPECT().Writers().Return([]contracts.FullConfig{
+		{Config: contracts.Config{Socket: "/var/run/mysqld/mysqld.sock", Database: "goravel", Username: "root", Sslmode: SslmodeRequired, SslCert: sslCert}},
+	}).Once()
+
+	testProcess := &testProcess{
+		handler: func(name string, args []string) contractsprocess.Result {
+			content, err := os.ReadFile(argValue(args, "--defaults-extra-file="))
+			assert.NoError(t, err)
+			assert.Contains(t, string(content), "socket=\"/var/run/mysqld/mysqld.sock\"\nssl\ndisable-ssl-verify-server-cert\nssl-cert="+quoteOption(sslCert)+"\n")
+			// Only the configured ssl files are written.
+			assert.NotContains(t, string(content), "ssl-key")
+
+			return process.NewResult(nil, 2, name, "", "Access denied")
+		},
+	}
+
+	mysql := &Mysql{
+		config:  mockConfigBuilder,
+		process: testProcess,
+		version: "5.5.5-10.11.6-MariaDB",
+	}
+	assert.EqualError(t, mysql.SchemaDump(filepath.Join(t.TempDir(), "schema.sql")), FailedToDumpSchema.Args("Access denied").Error())
+	assert.NotContains(t, testProcess.commands[0], "--set-gtid-purged=OFF")
+}
+
+func TestSslModeOptions(t *testing.T) {
+	tests := []struct {
+		sslmode       string
+		expectMysql   []string
+		expectMariadb []string
+	}{
+		{sslmode: SslmodeDisabled, expectMysql: []string{"ssl-mode=DISABLED"}, expectMariadb: []string{"skip-ssl"}},
+		{sslmode: SslmodePreferred, expectMysql: []string{"ssl-mode=PREFERRED"}},
+		{sslmode: SslmodeRequired, expectMysql: []string{"ssl-mode=REQUIRED"}, expectMariadb: []string{"ssl", "disable-ssl-verify-server-cert"}},
+		{sslmode: SslmodeVerifyCa, expectMysql: []string{"ssl-mode=VERIFY_CA"}, expectMariadb: []string{"ssl", "ssl-verify-server-cert"}},
+		{sslmode: SslmodeVerifyIdentity, expectMysql: []string{"ssl-mode=VERIFY_IDENTITY"}, expectMariadb: []string{"ssl", "ssl-verify-server-cert"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.sslmode, func(t *testing.T) {
+			assert.Equal(t, test.expectMysql, sslModeOptions(test.sslmode, Name))
+			assert.Equal(t, test.expectMariadb, sslModeOptions(test.sslmode, "MariaDB"))
+		})
+	}
+}
+
+func TestSchemaLoad(t *testing.T) {
+	mockConfigBuilder := mocks.NewConfigBuilder(t)
+	mockConfigBuilder.EXPECT().Writers().Return([]contracts.FullConfig{
+		{Config: contracts.Config{Host: "127.0.0.1", Port: 3306, Database: "goravel", Username: "root"}},
+	}).Twice()
+
+	testProcess := &testProcess{
+		handler: func(name string, args []string) contractsprocess.Result {
+			assert.Equal(t, "mysql", name)
+			assert.Equal(t, []string{"goravel", "--execute=source schema.sql"}, args[1:])
+
+			if len(args) > 0 && strings.HasPrefix(args[0], "--defaults-extra-file=") {
+				return process.NewResult(nil, 0, name, "", "")
+			}
+
+			return process.NewResult(nil, 1, name, "", "")
+		},
+	}
+
+	mysql := &Mysql{
+		config:  mockConfigBuilder,
+		process: testProcess,
+	}
+	assert.NoError(t, mysql.SchemaLoad("schema.sql"))
+
+	testProcess.handler = func(name string, args []string) contractsprocess.Result {
+		return process.NewResult(nil, 1, name, "", "Unknown database")
+	}
+	assert.EqualError(t, mysql.SchemaLoad("schema.sql"), FailedToLoadSchema.Args("schema.sql", "Unknown database").Error())
+
+	assert.ErrorIs(t, (&Mysql{}).SchemaLoad("schema.sql"), errors.ProcessFacadeNotSet)
+}
@@ -0,0 +1,327 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// foreignKeyRegex The foreign key definition in the output of SHOW CREATE TABLE.
+var foreignKeyRegex = regexp.MustCompile("^\\s*CONSTRAINT `(?:[^`]|``)*` FOREIGN KEY ")
+
+// SchemaDumper dumps the schema of a database via the introspection queries of Grammar and SHOW CREATE, so the
+// mysqldump binary isn't required. The statements are ordered by dependency: tables, foreign keys, views, routines
+// and triggers, then the rows of the migrations table.
+type SchemaDumper struct {
+	db              *sql.DB
+	grammar         *Grammar
+	database        string
+	migrationsTable string
+}
+
+func NewSchemaDumper(db *sql.DB, grammar *Grammar, database, migrationsTable string) *SchemaDumper {
+	return &SchemaDumper{
+		db:              db,
+		grammar:         grammar,
+		database:        database,
+		migrationsTable: migrationsTable,
+	}
+}
+
+// Dump returns the statements of the schema, they are separated by ";\n\n" without DELIMITER, the bodies of the
+// routines and triggers contain ';', so the dump should be loaded via multiStatements rather than the mysql client.
+func (r *SchemaDumper) Dump(ctx context.Context) (string, error) {
+	var (
+		statements  = []string{r.grammar.CompileDisableForeignKeyConstraints()}
+		foreignKeys []string
+	)
+
+	tables, err := r.names(ctx, r.grammar.CompileTables(r.database))
+	if err != nil {
+		return "", err
+	}
+	for _, table := range tables {
+		create, err := r.showCreate(ctx, "SHOW CREATE TABLE "+quoteIdentifier(table), "Create Table")
+		if err != nil {
+			return "", err
+		}
+
+		create, constraints := splitForeignKeys(create)
+		statements = append(statements, normalizeSchemaDump(create)+";")
+		for _, constraint := range constraints {
+			foreignKeys = append(foreignKeys, fmt.Sprintf("ALTER TABLE %s ADD %s;", quoteIdentifier(table), constraint))
+		}
+	}
+	statements = append(statements, foreignKeys...)
+
+	views, err := r.views(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, view := range views {
+		create, err := r.showCreate(ctx, "SHOW CREATE VIEW "+quoteIdentifier(view), "Create View")
+		if err != nil {
+			return "", err
+		}
+		statements = append(statements, r.unqualify(normalizeSchemaDump(create))+";")
+	}
+
+	routines, err := r.routines(ctx)
+	if err != nil {
+		return "", err
+	}
+	statements = append(statements, routines...)
+
+	triggers, err := r.names(ctx, r.grammar.CompileTriggers(r.database))
+	if err != nil {
+		return "", err
+	}
+	for _, trigger := range triggers {
+		create, err := r.showCreate(ctx, "SHOW CREATE TRIGGER "+quoteIdentifier(trigger), "SQL Original Statement")
+		if err != nil {
+			return "", err
+		}
+		statements = append(statements, normalizeSchemaDump(create)+";")
+	}
+
+	if slices.Contains(tables, r.migrationsTable) {
+		migrations, err := r.migrations(ctx)
+		if err != nil {
+			return "", err
+		}
+		statements = append(statements, migrations...)
+	}
+
+	statements = append(statements, r.grammar.CompileEnableForeignKeyConstraints())
+
+	return strings.Join(statements, "\n\n") + "\n", nil
+}
+
+func (r *SchemaDumper) migrations(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf("SELECT migration, batch FROM %s ORDER BY id", quoteIdentifier(r.migrationsTable)))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var statements []string
+	for rows.Next() {
+		var (
+			migration string
+			batch     int
+		)
+		if err := rows.Scan(&migration, &batch); err != nil {
+			return nil, err
+		}
+
+		statements = append(statements, fmt.Sprintf("INSERT INTO %s (`migration`, `batch`) VALUES (%s, %d);",
+			quoteIdentifier(r.migrationsTable), quoteString(migration), batch))
+	}
+
+	return statements, rows.Err()
+}
+
+// names returns the first column of the query, it's the name column of the introspection queries.
+func (r *SchemaDumper) names(ctx context.Context, query string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		names = append(names, values[0].String)
+	}
+
+	return names, rows.Err()
+}
+
+func (r *SchemaDumper) routines(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, r.grammar.CompileRoutines(r.database))
+	if err != nil {
+		return nil, err
+	}
+
+	type routine struct {
+		Name string
+		Type string
+	}
+	var routines []routine
+	for rows.Next() {
+		var routine routine
+		if err := rows.Scan(&routine.Name, &routine.Type); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		routines = append(routines, routine)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+
+	var statements []string
+	for _, routine := range routines {
+		routineType := strings.ToUpper(routine.Type)
+		// The column is "Create Procedure" or "Create Function".
+		column := "Create " + strings.ToUpper(routineType[:1]) + strings.ToLower(routineType[1:])
+		create, err := r.showCreate(ctx, fmt.Sprintf("SHOW CREATE %s %s", routineType, quoteIdentifier(routine.Name)), column)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, normalizeSchemaDump(create)+";")
+	}
+
+	return statements, nil
+}
+
+// showCreate returns the column of a SHOW CREATE statement, the columns are different between the statements.
+func (r *SchemaDumper) showCreate(ctx context.Context, query, column string) (string, error) {
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+	index := slices.Index(columns, column)
+	if index == -1 || !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", err
+		}
+
+		return "", FailedToDumpSchema.Args(fmt.Sprintf("%s returns no %s", query, column))
+	}
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return "", err
+	}
+	// The definition is NULL when the user doesn't have the privilege to see it.
+	if !values[index].Valid {
+		return "", FailedToDumpSchema.Args(fmt.Sprintf("%s returns no %s, please check the privileges", query, column))
+	}
+
+	return values[index].String, nil
+}
+
+// unqualify removes the database qualifier that SHOW CREATE VIEW adds, so the dump can be loaded into another
+// database.
+func (r *SchemaDumper) unqualify(create string) string {
+	return strings.ReplaceAll(create, quoteIdentifier(r.database)+".", "")
+}
+
+// views returns the views in dependency order, a view is placed after the views it references.
+func (r *SchemaDumper) views(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, r.grammar.CompileViews(r.database))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	definitions := make(map[string]string)
+	var names []string
+	for rows.Next() {
+		var (
+			name       string
+			definition sql.NullString
+		)
+		if err := rows.Scan(&name, &definition); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		definitions[name] = definition.String
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sortViews(names, definitions), nil
+}
+
+// sortViews sorts the views by dependency, the views without dependency between them keep the order of names.
+func sortViews(names []string, definitions map[string]string) []string {
+	var (
+		sorted  []string
+		visited = make(map[string]bool)
+		visit   func(name string)
+	)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+
+		for _, dependency := range names {
+			if dependency != name && strings.Contains(definitions[name], quoteIdentifier(dependency)) {
+				visit(dependency)
+			}
+		}
+		sorted = append(sorted, name)
+	}
+
+	for _, name := range names {
+		visit(name)
+	}
+
+	return sorted
+}
+
+// splitForeignKeys removes the foreign keys from the output of SHOW CREATE TABLE, they are added after all
+// tables are created, so the tables can be created in any order.
+func splitForeignKeys(create string) (string, []string) {
+	lines := strings.Split(create, "\n")
+	if len(lines) < 3 {
+		return create, nil
+	}
+
+	var definitions, foreignKeys []string
+	for _, line := range lines[1 : len(lines)-1] {
+		definition := strings.TrimSuffix(line, ",")
+		if foreignKeyRegex.MatchString(definition) {
+			foreignKeys = append(foreignKeys, strings.TrimSpace(definition))
+		} else {
+			definitions = append(definitions, definition)
+		}
+	}
+	if len(foreignKeys) == 0 {
+		return create, nil
+	}
+
+	return lines[0] + "\n" + strings.Join(definitions, ",\n") + "\n" + lines[len(lines)-1], foreignKeys
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
@@ -0,0 +1,145 @@
+package mysql
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	mocksconfig "github.com/goravel/framework/mocks/config"
+	"github.com/goravel/framework/process"
+	"github.com/goravel/framework/testing/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goravel/mysql/contracts"
+	mocks "github.com/goravel/mysql/mocks"
+)
+
+func TestSchemaDumpNative(t *testing.T) {
+	t.Parallel()
+	writer := contracts.FullConfig{
+		Config: contracts.Config{
+			Host:     "localhost",
+			Database: "goravel",
+			Username: "root",
+			Password: "Framework!123",
+		},
+		Loc:     "UTC",
+		Charset: "utf8mb4",
+	}
+
+	docker := NewDocker(nil, process.New(), writer.Database, writer.Username, writer.Password)
+	require.NoError(t, docker.Build())
+
+	writer.Port = docker.databaseConfig.Port
+	instance, err := docker.connect()
+	require.NoError(t, err)
+
+	for _, statement := range []string{
+		"CREATE TABLE `users` (`id` bigint unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY, `name` varchar(255) NOT NULL)",
+		"CREATE TABLE `articles` (`id` bigint unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY, `user_id` bigint unsigned NOT NULL, " +
+			"CONSTRAINT `articles_user_id_foreign` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`))",
+		"CREATE TABLE `migrations` (`id` int unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY, `migration` varchar(255) NOT NULL, `batch` int NOT NULL)",
+		"INSERT INTO `users` (`name`) VALUES ('goravel')",
+		"INSERT INTO `migrations` (`migration`, `batch`) VALUES ('20240101000000_create_users_table', 1)",
+		"CREATE VIEW `z_users` AS SELECT `id`, `name` FROM `users`",
+		"CREATE VIEW `a_users` AS SELECT `id` FROM `z_users`",
+		"CREATE PROCEDURE `count_users`() BEGIN SELECT COUNT(*) FROM `users`; END",
+		"CREATE TRIGGER `users_before_insert` BEFORE INSERT ON `users` FOR EACH ROW BEGIN SET NEW.`name` = TRIM(NEW.`name`); END",
+		"CREATE DATABASE `goravel_loaded`",
+	} {
+		assert.NoError(t, instance.Exec(statement).Error, statement)
+	}
+	assert.NoError(t, docker.close(instance))
+
+	mockConfig := mocksconfig.NewConfig(t)
+	mockConfig.EXPECT().GetString("database.migrations.table", "migrations").Return("migrations").Once()
+	mockConfigBuilder := mocks.NewConfigBuilder(t)
+	mockConfigBuilder.EXPECT().Config().Return(mockConfig).Once()
+	mockConfigBuilder.EXPECT().Writers().Return([]contracts.FullConfig{writer})
+
+	mysql := &Mysql{
+		config: mockConfigBuilder,
+		log:    utils.NewTestLog(),
+	}
+	dumpPath := filepath.Join(t.TempDir(), "schema.sql")
+	assert.NoError(t, mysql.SchemaDumpNative(dumpPath))
+
+	dump, err := os.ReadFile(dumpPath)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(dump), "AUTO_INCREMENT=")
+	assert.NotContains(t, string(dump), "DEFINER=`")
+	assert.Contains(t, string(dump), "ALTER TABLE `articles` ADD CONSTRAINT `articles_user_id_foreign` FOREIGN KEY")
+	assert.Less(t, strings.Index(string(dump), "VIEW `z_users`"), strings.Index(string(dump), "VIEW `a_users`"))
+
+	loaded := writer
+	loaded.Database = "goravel_loaded"
+	mockLoadedConfigBuilder := mocks.NewConfigBuilder(t)
+	mockLoadedConfigBuilder.EXPECT().Writers().Return([]contracts.FullConfig{loaded}).Once()
+
+	mysql.config = mockLoadedConfigBuilder
+	assert.NoError(t, mysql.SchemaLoadNative(dumpPath))
+
+	docker.databaseConfig.Database = loaded.Database
+	instance, err = docker.connect()
+	require.NoError(t, err)
+
+	var migrations int64
+	assert.NoError(t, instance.Table("migrations").Count(&migrations).Error)
+	assert.Equal(t, int64(1), migrations)
+
+	assert.NoError(t, instance.Exec("INSERT INTO `users` (`name`) VALUES (' goravel ')").Error)
+	var name string
+	assert.NoError(t, instance.Raw("SELECT `name` FROM `a_users` JOIN `users` USING (`id`)").Scan(&name).Error)
+	assert.Equal(t, "goravel", name)
+
+	assert.NoError(t, docker.close(instance))
+	assert.NoError(t, docker.Shutdown())
+}
+
+func TestSplitForeignKeys(t *testing.T) {
+	create := "CREATE TABLE `articles` (\n" +
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n" +
+		"  `user_id` bigint unsigned NOT NULL,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  KEY `articles_user_id_foreign` (`user_id`),\n" +
+		"  CONSTRAINT `articles_user_id_foreign` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE,\n" +
+		"  CONSTRAINT `articles_id_check` CHECK ((`id` > 0))\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"
+
+	table, foreignKeys := splitForeignKeys(create)
+	assert.Equal(t, "CREATE TABLE `articles` (\n"+
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n"+
+		"  `user_id` bigint unsigned NOT NULL,\n"+
+		"  PRIMARY KEY (`id`),\n"+
+		"  KEY `articles_user_id_foreign` (`user_id`),\n"+
+		"  CONSTRAINT `articles_id_check` CHECK ((`id` > 0))\n"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4", table)
+	assert.Equal(t, []string{
+		"CONSTRAINT `articles_user_id_foreign` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE",
+	}, foreignKeys)
+
+	create = "CREATE TABLE `users` (\n  `id` bigint unsigned NOT NULL\n) ENGINE=InnoDB"
+	table, foreignKeys = splitForeignKeys(create)
+	assert.Equal(t, create, table)
+	assert.Empty(t, foreignKeys)
+}
+
+func TestSortViews(t *testing.T) {
+	names := []string{"a_users", "b_users", "c_users"}
+	definitions := map[string]string{
+		"a_users": "select `goravel`.`c_users`.`id` AS `id` from `goravel`.`c_users`",
+		"b_users": "select `goravel`.`users`.`id` AS `id` from `goravel`.`users`",
+		"c_users": "select `goravel`.`b_users`.`id` AS `id` from `goravel`.`b_users`",
+	}
+
+	assert.Equal(t, []string{"b_users", "c_users", "a_users"}, sortViews(names, definitions))
+}
+
+func TestSchemaDumperUnqualify(t *testing.T) {
+	dumper := NewSchemaDumper(nil, nil, "goravel", "migrations")
+
+	assert.Equal(t, "CREATE VIEW `users_view` AS select `users`.`id` AS `id` from `users`",
+		dumper.unqualify("CREATE VIEW `users_view` AS select `goravel`.`users`.`id` AS `id` from `goravel`.`users`"))
+}
@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"github.com/goravel/framework/contracts/binding"
+	"github.com/goravel/framework/contracts/console"
 	"github.com/goravel/framework/contracts/foundation"
 	"github.com/goravel/framework/errors"
 )
@@ -51,5 +52,18 @@ func (r *ServiceProvider) Register(app foundation.Application) {
 }
 
 func (r *ServiceProvider) Boot(app foundation.Application) {
+	r.registerCommands(app)
+}
+
+func (r *ServiceProvider) registerCommands(app foundation.Application) {
+	config := app.MakeConfig()
+	log := app.MakeLog()
+	if config == nil || log == nil {
+		return
+	}
 
+	app.Commands([]console.Command{
+		NewSchemaDumpCommand(config, log, app.MakeProcess()),
+		NewSchemaLoadCommand(config, log, app.MakeProcess()),
+	})
 }
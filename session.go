@@ -0,0 +1,106 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/goravel/mysql/contracts"
+)
+
+var transactionIsolations = []string{"READ UNCOMMITTED", "READ COMMITTED", "REPEATABLE READ", "SERIALIZABLE"}
+
+// sessionStatements compiles the session config to the statements that should be executed on every new connection.
+func sessionStatements(fullConfig contracts.FullConfig) ([]string, error) {
+	session := fullConfig.Session
+
+	var statements []string
+	if session.SqlMode != "" {
+		sqlMode, err := quoteSessionValue(session.SqlMode)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, "SET SESSION sql_mode = "+sqlMode)
+	}
+	if session.TimeZone != "" {
+		timeZone, err := quoteSessionValue(session.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, "SET time_zone = "+timeZone)
+	}
+	if session.TransactionIsolation != "" {
+		// Both MySQL and MariaDB support this syntax, transaction_isolation is not available on old MariaDB versions.
+		isolation := strings.ToUpper(strings.ReplaceAll(session.TransactionIsolation, "-", " "))
+		if !slices.Contains(transactionIsolations, isolation) {
+			return nil, InvalidTransactionIsolation.Args(session.TransactionIsolation)
+		}
+		statements = append(statements, "SET SESSION TRANSACTION ISOLATION LEVEL "+isolation)
+	}
+	if session.Collation != "" {
+		charset := fullConfig.Charset
+		if charset == "" {
+			charset = "utf8mb4"
+		}
+		charset, err := quoteSessionValue(charset)
+		if err != nil {
+			return nil, err
+		}
+		collation, err := quoteSessionValue(session.Collation)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, fmt.Sprintf("SET NAMES %s COLLATE %s", charset, collation))
+	}
+
+	return append(statements, session.Statements...), nil
+}
+
+// quoteSessionValue quotes the value of a session variable, the backslashes are rejected rather than escaped: they are
+// literal when the sql_mode of the server contains NO_BACKSLASH_ESCAPES, which isn't known before connecting.
+func quoteSessionValue(value string) (string, error) {
+	if strings.Contains(value, "\\") {
+		return "", InvalidSessionValue.Args(value)
+	}
+
+	return quoteString(value), nil
+}
+
+// quoteString quotes the value as a MySQL string literal.
+func quoteString(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, "'", "''")
+
+	return "'" + value + "'"
+}
+
+// sessionConnector executes the session statements every time a physical connection is opened,
+// so they are applied to all connections of the pool.
+type sessionConnector struct {
+	driver.Connector
+	statements []string
+}
+
+func (r *sessionConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := r.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	execer, ok := conn.(driver.ExecerContext)
+	if !ok {
+		_ = conn.Close()
+		return nil, SessionStatementsNotSupported
+	}
+
+	for _, statement := range r.statements {
+		if _, err := execer.ExecContext(ctx, statement, nil); err != nil {
+			_ = conn.Close()
+			return nil, FailedToExecuteSessionStatement.Args(statement, err)
+		}
+	}
+
+	return conn, nil
+}
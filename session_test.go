@@ -0,0 +1,153 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goravel/mysql/contracts"
+)
+
+func TestSessionStatements(t *testing.T) {
+	tests := []struct {
+		name             string
+		fullConfig       contracts.FullConfig
+		expectStatements []string
+		expectErr        error
+	}{
+		{
+			name: "session is empty",
+		},
+		{
+			name: "with all fields",
+			fullConfig: contracts.FullConfig{
+				Charset: "utf8mb4",
+				Session: contracts.Session{
+					SqlMode:              "STRICT_TRANS_TABLES,NO_ZERO_DATE",
+					TimeZone:             "+00:00",
+					TransactionIsolation: "read-committed",
+					Collation:            "utf8mb4_0900_ai_ci",
+					Statements:           []string{"SET SESSION innodb_lock_wait_timeout = 10"},
+				},
+			},
+			expectStatements: []string{
+				"SET SESSION sql_mode = 'STRICT_TRANS_TABLES,NO_ZERO_DATE'",
+				"SET time_zone = '+00:00'",
+				"SET SESSION TRANSACTION ISOLATION LEVEL READ COMMITTED",
+				"SET NAMES 'utf8mb4' COLLATE 'utf8mb4_0900_ai_ci'",
+				"SET SESSION innodb_lock_wait_timeout = 10",
+			},
+		},
+		{
+			name: "escape values",
+			fullConfig: contracts.FullConfig{
+				Session: contracts.Session{
+					TimeZone: `Asia/Shanghai' or '1`,
+				},
+			},
+			expectStatements: []string{
+				`SET time_zone = 'Asia/Shanghai'' or ''1'`,
+			},
+		},
+		{
+			name: "backslash in values",
+			fullConfig: contracts.FullConfig{
+				Session: contracts.Session{
+					TimeZone: `Asia\Shanghai`,
+				},
+			},
+			expectErr: InvalidSessionValue.Args(`Asia\Shanghai`),
+		},
+		{
+			name: "invalid transaction isolation",
+			fullConfig: contracts.FullConfig{
+				Session: contracts.Session{
+					TransactionIsolation: "SNAPSHOT",
+				},
+			},
+			expectErr: InvalidTransactionIsolation.Args("SNAPSHOT"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statements, err := sessionStatements(test.fullConfig)
+			if test.expectErr != nil {
+				assert.EqualError(t, err, test.expectErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectStatements, statements)
+		})
+	}
+}
+
+func TestSessionConnector(t *testing.T) {
+	t.Run("execute statements", func(t *testing.T) {
+		conn := &testConn{}
+		connector := &sessionConnector{
+			Connector:  &testConnector{conn: conn},
+			statements: []string{"SET time_zone = '+00:00'", "SET SESSION sql_mode = 'TRADITIONAL'"},
+		}
+
+		result, err := connector.Connect(context.Background())
+		assert.NoError(t, err)
+		assert.Same(t, conn, result)
+		assert.Equal(t, []string{"SET time_zone = '+00:00'", "SET SESSION sql_mode = 'TRADITIONAL'"}, conn.queries)
+		assert.False(t, conn.closed)
+	})
+
+	t.Run("close the connection when failed to execute statements", func(t *testing.T) {
+		conn := &testConn{err: errors.New("unknown system variable")}
+		connector := &sessionConnector{
+			Connector:  &testConnector{conn: conn},
+			statements: []string{"SET unknown = 1"},
+		}
+
+		result, err := connector.Connect(context.Background())
+		assert.EqualError(t, err, FailedToExecuteSessionStatement.Args("SET unknown = 1", conn.err).Error())
+		assert.Nil(t, result)
+		assert.True(t, conn.closed)
+	})
+}
+
+type testConnector struct {
+	conn driver.Conn
+}
+
+func (r *testConnector) Connect(context.Context) (driver.Conn, error) {
+	return r.conn, nil
+}
+
+func (r *testConnector) Driver() driver.Driver {
+	return nil
+}
+
+type testConn struct {
+	err     error
+	queries []string
+	closed  bool
+}
+
+func (r *testConn) Prepare(string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (r *testConn) Close() error {
+	r.closed = true
+
+	return nil
+}
+
+func (r *testConn) Begin() (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+func (r *testConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	r.queries = append(r.queries, query)
+
+	return driver.RowsAffected(0), r.err
+}
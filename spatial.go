@@ -0,0 +1,55 @@
+package mysql
+
+import (
+	"fmt"
+)
+
+// Geometry A geometry value that is bound to a query rather than interpolated, it's WKT or WKB with an optional SRID.
+// The coordinates follow the axis order of the SRID, e.g. latitude first for 4326 in MySQL 8.
+type Geometry struct {
+	srid int
+	wkb  []byte
+	wkt  string
+}
+
+// GeometryFromText returns a geometry of the WKT, e.g. "POINT(39.9 116.4)", srid 0 means no SRID.
+func GeometryFromText(wkt string, srid int) Geometry {
+	return Geometry{srid: srid, wkt: wkt}
+}
+
+// GeometryFromWKB returns a geometry of the WKB, srid 0 means no SRID.
+func GeometryFromWKB(wkb []byte, srid int) Geometry {
+	return Geometry{srid: srid, wkb: wkb}
+}
+
+// CompileContains compiles whether the geometry of the column contains the geometry, the result can be passed to Where:
+//
+//	sql, args := grammar.CompileContains("area", mysql.GeometryFromText("POINT(39.9 116.4)", 4326))
+//	facades.DB().Table("regions").Where(sql, args...).Get(&regions)
+func (r *Grammar) CompileContains(column string, geometry Geometry) (string, []any) {
+	sql, args := r.compileGeometry(geometry)
+
+	return fmt.Sprintf("st_contains(%s, %s)", r.wrap.Column(column), sql), args
+}
+
+// CompileDistanceSphere compiles the spherical distance in meters between the point of the column and the point:
+//
+//	sql, args := grammar.CompileDistanceSphere("location", mysql.GeometryFromText("POINT(39.9 116.4)", 4326))
+//	facades.DB().Table("places").Where(sql+" <= ?", append(args, 1000)...).Get(&places)
+func (r *Grammar) CompileDistanceSphere(column string, geometry Geometry) (string, []any) {
+	sql, args := r.compileGeometry(geometry)
+
+	return fmt.Sprintf("st_distance_sphere(%s, %s)", r.wrap.Column(column), sql), args
+}
+
+func (r *Grammar) compileGeometry(geometry Geometry) (string, []any) {
+	function, args := "st_geomfromtext", []any{geometry.wkt}
+	if geometry.wkb != nil {
+		function, args = "st_geomfromwkb", []any{geometry.wkb}
+	}
+	if geometry.srid == 0 {
+		return function + "(?)", args
+	}
+
+	return function + "(?, ?)", append(args, geometry.srid)
+}
@@ -0,0 +1,171 @@
+package mysql
+
+import (
+	"github.com/goravel/framework/contracts/database/driver"
+)
+
+const (
+	AlgorithmCopy    = "copy"
+	AlgorithmInplace = "inplace"
+	AlgorithmInstant = "instant"
+	LockExclusive    = "exclusive"
+	LockNone         = "none"
+	LockShared       = "shared"
+)
+
+// tables The MySQL table options of the blueprints, they are found by the blueprint when it's compiled.
+var tables = newRegistry[TableDefinition]()
+
+type TableDefinition struct {
+	alter            AlterOptions
+	autoIncrement    *uint64
+	charset          string
+	collation        string
+	engine           string
+	indexParts       map[string][]IndexPart
+	indexes          map[string]*AlterOptions
+	keyBlockSize     int
+	partitioning     *partitioning
+	rowFormat        string
+	spatialIndexes   map[string]bool
+	statsPersistent  *bool
+	systemVersioning bool
+}
+
+// Table returns the MySQL table options of a blueprint, they are applied when the table is created:
+//
+//	facades.Schema().Create("users", func(table schema.Blueprint) {
+//		mysql.Table(table).Engine("InnoDB").Collation("utf8mb4_0900_ai_ci")
+//	})
+func Table(blueprint driver.Blueprint) *TableDefinition {
+	return tables.getOrCreate(blueprint)
+}
+
+// AlterOptions The ALGORITHM and LOCK clauses of an alter table statement.
+type AlterOptions struct {
+	algorithm string
+	lock      string
+}
+
+// Algorithm sets the ALGORITHM of the statement: AlgorithmInstant, AlgorithmInplace or AlgorithmCopy.
+func (r *AlterOptions) Algorithm(algorithm string) *AlterOptions {
+	r.algorithm = algorithm
+
+	return r
+}
+
+// Lock sets the LOCK of the statement: LockNone, LockShared or LockExclusive.
+func (r *AlterOptions) Lock(lock string) *AlterOptions {
+	r.lock = lock
+
+	return r
+}
+
+// Algorithm sets the ALGORITHM of all alter table statements of the blueprint, it overrides
+// database.connections.{connection}.online_ddl.algorithm. The statement fails if the algorithm isn't supported,
+// rather than falling back to a blocking one.
+func (r *TableDefinition) Algorithm(algorithm string) *TableDefinition {
+	r.alter.algorithm = algorithm
+
+	return r
+}
+
+// AutoIncrement sets the initial AUTO_INCREMENT value of the table.
+func (r *TableDefinition) AutoIncrement(value uint64) *TableDefinition {
+	r.autoIncrement = &value
+
+	return r
+}
+
+// Charset sets the default character set of the table.
+func (r *TableDefinition) Charset(charset string) *TableDefinition {
+	r.charset = charset
+
+	return r
+}
+
+// Collation sets the default collation of the table, it overrides database.connections.{connection}.collation.
+func (r *TableDefinition) Collation(collation string) *TableDefinition {
+	r.collation = collation
+
+	return r
+}
+
+// Engine sets the storage engine of the table, it overrides database.connections.{connection}.engine.
+func (r *TableDefinition) Engine(engine string) *TableDefinition {
+	r.engine = engine
+
+	return r
+}
+
+// Index returns the ALGORITHM and LOCK clauses of the statements that add, drop or rename the index (or the foreign
+// key), they override the ones of the blueprint:
+//
+//	table.Index("email").Name("users_email_index")
+//	mysql.Table(table).Index("users_email_index").Algorithm(mysql.AlgorithmInplace).Lock(mysql.LockNone)
+func (r *TableDefinition) Index(name string) *AlterOptions {
+	if r.indexes == nil {
+		r.indexes = make(map[string]*AlterOptions)
+	}
+	if _, ok := r.indexes[name]; !ok {
+		r.indexes[name] = &AlterOptions{}
+	}
+
+	return r.indexes[name]
+}
+
+// KeyBlockSize sets the KEY_BLOCK_SIZE of the table in kilobytes.
+func (r *TableDefinition) KeyBlockSize(size int) *TableDefinition {
+	r.keyBlockSize = size
+
+	return r
+}
+
+// Lock sets the LOCK of all alter table statements of the blueprint, it overrides
+// database.connections.{connection}.online_ddl.lock.
+func (r *TableDefinition) Lock(lock string) *TableDefinition {
+	r.alter.lock = lock
+
+	return r
+}
+
+// RowFormat sets the ROW_FORMAT of the table, e.g. DYNAMIC, COMPRESSED.
+func (r *TableDefinition) RowFormat(format string) *TableDefinition {
+	r.rowFormat = format
+
+	return r
+}
+
+// SpatialIndex marks the index as a SPATIAL index, the index is found by its name:
+//
+//	table.Index("location").Name("places_location_spatial")
+//	mysql.Table(table).SpatialIndex("places_location_spatial")
+func (r *TableDefinition) SpatialIndex(index string) *TableDefinition {
+	if r.spatialIndexes == nil {
+		r.spatialIndexes = make(map[string]bool)
+	}
+	r.spatialIndexes[index] = true
+
+	return r
+}
+
+// StatsPersistent sets whether the statistics of the table are persisted to disk.
+func (r *TableDefinition) StatsPersistent(persistent bool) *TableDefinition {
+	r.statsPersistent = &persistent
+
+	return r
+}
+
+// SystemVersioning keeps the history of the rows of the table, it's only supported by MariaDB and is ignored by MySQL:
+//
+//	SELECT * FROM users FOR SYSTEM_TIME AS OF '2025-01-01 00:00:00'
+func (r *TableDefinition) SystemVersioning() *TableDefinition {
+	r.systemVersioning = true
+
+	return r
+}
+
+// tableDefinition returns the MySQL table options of the blueprint, nil if they aren't set.
+func tableDefinition(blueprint driver.Blueprint) *TableDefinition {
+	return tables.get(blueprint)
+}
@@ -0,0 +1,167 @@
+package mysql
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strings"
+	"sync"
+
+	gomysql "github.com/go-sql-driver/mysql"
+
+	"github.com/goravel/mysql/contracts"
+)
+
+const (
+	SslmodeDisabled       = "disabled"
+	SslmodePreferred      = "preferred"
+	SslmodeRequired       = "required"
+	SslmodeVerifyCa       = "verify-ca"
+	SslmodeVerifyIdentity = "verify-identity"
+)
+
+// registeredTLSConfigs The TLS configurations that are registered with go-sql-driver and the versions of their files, the
+// name is derived from the TLS config of the node, so a node is registered again only if its files are changed, e.g.
+// the certificates are rotated, rather than every time its dialector is built.
+var registeredTLSConfigs sync.Map
+
+// registerTLSConfig registers the TLS configuration of the node with go-sql-driver and returns
+// the name that should be used as the tls parameter of the DSN, an empty name means TLS is not configured.
+func registerTLSConfig(fullConfig contracts.FullConfig) (string, error) {
+	switch fullConfig.Sslmode {
+	case "":
+		return "", nil
+	case SslmodeDisabled:
+		return "false", nil
+	}
+
+	name := tlsConfigName(fullConfig)
+	version := tlsFilesVersion(fullConfig)
+	if registered, ok := registeredTLSConfigs.Load(name); ok && registered == version {
+		return name, nil
+	}
+
+	tlsConfig, err := tlsConfig(fullConfig)
+	if err != nil {
+		return "", err
+	}
+
+	if err := gomysql.RegisterTLSConfig(name, tlsConfig); err != nil {
+		return "", FailedToRegisterTLS.Args(name, err)
+	}
+	registeredTLSConfigs.Store(name, version)
+
+	return name, nil
+}
+
+func tlsConfig(fullConfig contracts.FullConfig) (*tls.Config, error) {
+	config := &tls.Config{}
+
+	switch fullConfig.Sslmode {
+	case SslmodePreferred, SslmodeRequired:
+		config.InsecureSkipVerify = true
+	case SslmodeVerifyCa, SslmodeVerifyIdentity:
+		if fullConfig.SslCa == "" {
+			return nil, SslCaRequired.Args(fullConfig.Sslmode)
+		}
+	default:
+		return nil, InvalidSslmode.Args(fullConfig.Sslmode)
+	}
+
+	if fullConfig.SslCa != "" {
+		pem, err := os.ReadFile(fullConfig.SslCa)
+		if err != nil {
+			return nil, FailedToLoadSslCa.Args(fullConfig.SslCa, err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, FailedToLoadSslCa.Args(fullConfig.SslCa, "no valid certificate found")
+		}
+		config.RootCAs = pool
+	}
+
+	if fullConfig.SslCert != "" || fullConfig.SslKey != "" {
+		// The key is read from the cert file when it's not set, the same as the MySQL clients.
+		keyFile := fullConfig.SslKey
+		if keyFile == "" {
+			keyFile = fullConfig.SslCert
+		}
+		certificate, err := tls.LoadX509KeyPair(fullConfig.SslCert, keyFile)
+		if err != nil {
+			return nil, FailedToLoadSslCert.Args(fullConfig.SslCert, fullConfig.SslKey, err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	switch fullConfig.Sslmode {
+	case SslmodeVerifyCa:
+		// Verify the certificate chain only, the host name is not checked in this mode.
+		config.InsecureSkipVerify = true
+		config.VerifyPeerCertificate = verifyCertificateChain(config.RootCAs)
+	case SslmodeVerifyIdentity:
+		config.ServerName = fullConfig.SslServerName
+		if config.ServerName == "" {
+			config.ServerName = fullConfig.Host
+		}
+	}
+
+	return config, nil
+}
+
+func tlsConfigName(fullConfig contracts.FullConfig) string {
+	hash := fnv.New32a()
+	_, _ = fmt.Fprintf(hash, "%s|%s|%d|%s|%s|%s|%s|%s", fullConfig.Connection, fullConfig.Host, fullConfig.Port,
+		fullConfig.Sslmode, fullConfig.SslCa, fullConfig.SslCert, fullConfig.SslKey, fullConfig.SslServerName)
+
+	return fmt.Sprintf("goravel_%s_%x", fullConfig.Connection, hash.Sum32())
+}
+
+// tlsFilesVersion returns the modification times and sizes of the ca, cert and key files of the node, it changes when
+// a file is replaced at the same path.
+func tlsFilesVersion(fullConfig contracts.FullConfig) string {
+	var version strings.Builder
+	for _, file := range []string{fullConfig.SslCa, fullConfig.SslCert, fullConfig.SslKey} {
+		if file == "" {
+			continue
+		}
+		if info, err := os.Stat(file); err == nil {
+			_, _ = fmt.Fprintf(&version, "%s|%d|%d;", file, info.ModTime().UnixNano(), info.Size())
+		} else {
+			_, _ = fmt.Fprintf(&version, "%s|%s;", file, err)
+		}
+	}
+
+	return version.String()
+}
+
+func verifyCertificateChain(roots *x509.CertPool) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return fmt.Errorf("no server certificate found")
+		}
+
+		certificates := make([]*x509.Certificate, len(rawCerts))
+		for i, rawCert := range rawCerts {
+			certificate, err := x509.ParseCertificate(rawCert)
+			if err != nil {
+				return err
+			}
+			certificates[i] = certificate
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, certificate := range certificates[1:] {
+			intermediates.AddCert(certificate)
+		}
+
+		_, err := certificates[0].Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+		})
+
+		return err
+	}
+}
@@ -0,0 +1,235 @@
+package mysql
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goravel/mysql/contracts"
+)
+
+func TestRegisterTLSConfig(t *testing.T) {
+	caFile := createTestCa(t)
+
+	tests := []struct {
+		name       string
+		fullConfig contracts.FullConfig
+		expectName string
+		expectErr  error
+	}{
+		{
+			name:       "sslmode is empty",
+			fullConfig: contracts.FullConfig{},
+		},
+		{
+			name: "sslmode is disabled",
+			fullConfig: contracts.FullConfig{
+				Config: contracts.Config{Sslmode: SslmodeDisabled},
+			},
+			expectName: "false",
+		},
+		{
+			name: "sslmode is invalid",
+			fullConfig: contracts.FullConfig{
+				Config: contracts.Config{Sslmode: "require"},
+			},
+			expectErr: InvalidSslmode.Args("require"),
+		},
+		{
+			name: "sslmode is verify-ca without ca",
+			fullConfig: contracts.FullConfig{
+				Config: contracts.Config{Sslmode: SslmodeVerifyCa},
+			},
+			expectErr: SslCaRequired.Args(SslmodeVerifyCa),
+		},
+		{
+			name: "sslmode is required",
+			fullConfig: contracts.FullConfig{
+				Connection: "mysql",
+				Config:     contracts.Config{Host: "localhost", Port: 3306, Sslmode: SslmodeRequired},
+			},
+			expectName: tlsConfigName(contracts.FullConfig{
+				Connection: "mysql",
+				Config:     contracts.Config{Host: "localhost", Port: 3306, Sslmode: SslmodeRequired},
+			}),
+		},
+		{
+			name: "sslmode is verify-identity",
+			fullConfig: contracts.FullConfig{
+				Connection: "mysql",
+				Config:     contracts.Config{Host: "localhost", Port: 3306, Sslmode: SslmodeVerifyIdentity, SslCa: caFile},
+			},
+			expectName: tlsConfigName(contracts.FullConfig{
+				Connection: "mysql",
+				Config:     contracts.Config{Host: "localhost", Port: 3306, Sslmode: SslmodeVerifyIdentity, SslCa: caFile},
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			name, err := registerTLSConfig(test.fullConfig)
+			if test.expectErr != nil {
+				assert.EqualError(t, err, test.expectErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectName, name)
+		})
+	}
+}
+
+func TestRegisterTLSConfigOnce(t *testing.T) {
+	caFile := createTestCa(t)
+	fullConfig := contracts.FullConfig{
+		Connection: "mysql",
+		Config:     contracts.Config{Host: "once", Port: 3306, Sslmode: SslmodeVerifyCa, SslCa: caFile},
+	}
+
+	name, err := registerTLSConfig(fullConfig)
+	assert.NoError(t, err)
+
+	again, err := registerTLSConfig(fullConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, name, again)
+
+	// The ca file is read again once it's replaced.
+	assert.NoError(t, os.WriteFile(caFile, []byte("invalid"), 0644))
+	assert.NoError(t, os.Chtimes(caFile, time.Now(), time.Now().Add(time.Minute)))
+
+	_, err = registerTLSConfig(fullConfig)
+	assert.ErrorContains(t, err, "failed to load ssl ca")
+}
+
+func TestTlsConfig(t *testing.T) {
+	caFile := createTestCa(t)
+
+	t.Run("required skips verification", func(t *testing.T) {
+		config, err := tlsConfig(contracts.FullConfig{
+			Config: contracts.Config{Host: "localhost", Sslmode: SslmodeRequired},
+		})
+		assert.NoError(t, err)
+		assert.True(t, config.InsecureSkipVerify)
+		assert.Nil(t, config.RootCAs)
+	})
+
+	t.Run("verify-ca verifies the chain only", func(t *testing.T) {
+		config, err := tlsConfig(contracts.FullConfig{
+			Config: contracts.Config{Host: "localhost", Sslmode: SslmodeVerifyCa, SslCa: caFile},
+		})
+		assert.NoError(t, err)
+		assert.True(t, config.InsecureSkipVerify)
+		assert.NotNil(t, config.RootCAs)
+		assert.NotNil(t, config.VerifyPeerCertificate)
+		assert.Error(t, config.VerifyPeerCertificate(nil, nil))
+	})
+
+	t.Run("verify-identity uses host as server name", func(t *testing.T) {
+		config, err := tlsConfig(contracts.FullConfig{
+			Config: contracts.Config{Host: "localhost", Sslmode: SslmodeVerifyIdentity, SslCa: caFile},
+		})
+		assert.NoError(t, err)
+		assert.False(t, config.InsecureSkipVerify)
+		assert.Equal(t, "localhost", config.ServerName)
+	})
+
+	t.Run("verify-identity uses ssl server name", func(t *testing.T) {
+		config, err := tlsConfig(contracts.FullConfig{
+			Config: contracts.Config{Host: "127.0.0.1", Sslmode: SslmodeVerifyIdentity, SslCa: caFile, SslServerName: "mysql.goravel.dev"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "mysql.goravel.dev", config.ServerName)
+	})
+
+	t.Run("ca does not exist", func(t *testing.T) {
+		_, err := tlsConfig(contracts.FullConfig{
+			Config: contracts.Config{Sslmode: SslmodeVerifyIdentity, SslCa: filepath.Join(t.TempDir(), "ca.pem")},
+		})
+		assert.ErrorContains(t, err, "failed to load ssl ca")
+	})
+
+	t.Run("client key is read from the cert file", func(t *testing.T) {
+		config, err := tlsConfig(contracts.FullConfig{
+			Config: contracts.Config{Sslmode: SslmodeRequired, SslCert: createTestKeyPair(t)},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, config.Certificates, 1)
+	})
+
+	t.Run("client key pair does not exist", func(t *testing.T) {
+		_, err := tlsConfig(contracts.FullConfig{
+			Config: contracts.Config{Sslmode: SslmodeRequired, SslCert: "cert.pem", SslKey: "key.pem"},
+		})
+		assert.ErrorContains(t, err, "failed to load ssl cert")
+	})
+}
+
+func TestTlsConfigName(t *testing.T) {
+	writer := contracts.FullConfig{
+		Connection: "mysql",
+		Config:     contracts.Config{Host: "writer", Port: 3306, Sslmode: SslmodeRequired},
+	}
+	reader := contracts.FullConfig{
+		Connection: "mysql",
+		Config:     contracts.Config{Host: "reader", Port: 3306, Sslmode: SslmodeRequired},
+	}
+
+	assert.Equal(t, tlsConfigName(writer), tlsConfigName(writer))
+	assert.NotEqual(t, tlsConfigName(writer), tlsConfigName(reader))
+}
+
+func createTestCa(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "goravel"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+
+	return file
+}
+
+// createTestKeyPair writes a client certificate and its key to the same file.
+func createTestKeyPair(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "goravel-client"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "client.pem")
+	content := append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})...)
+	assert.NoError(t, os.WriteFile(file, content, 0600))
+
+	return file
+}
@@ -0,0 +1,132 @@
+package mysql
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Upsert The columns that are updated when the inserted row conflicts with an existing one on a unique key.
+type Upsert struct {
+	// Update The columns that are set to the inserted values, all inserted columns are updated when both Update and
+	// Expressions are empty.
+	Update []string
+	// Expressions The columns that are set to raw expressions, the inserted value is referenced via CompileInserted:
+	//
+	//	map[string]string{"hits": "`hits` + " + grammar.CompileInserted("hits")}
+	Expressions map[string]string
+}
+
+// UpsertResult The rows that are affected by an upsert statement, Inserted, Updated and Unchanged are set only if the
+// affected rows can be split exactly, see NewUpsertResult.
+type UpsertResult struct {
+	Affected  int64
+	Inserted  int64
+	Updated   int64
+	Unchanged int64
+	Exact     bool
+}
+
+// NewUpsertResult derives the result from the affected rows of an upsert statement of rows rows, MySQL counts 1 for an
+// inserted row, 2 for an updated row and 0 for an unchanged row. The split is exact for a single row, or when all rows
+// are unchanged or updated, otherwise only Affected is set, e.g. 2 of 2 rows is either 2 inserted rows or an updated
+// row and an unchanged one. The clientFoundRows DSN parameter counts the unchanged rows as 1, so they are reported as
+// inserted.
+func NewUpsertResult(rows int, affected int64) UpsertResult {
+	result := UpsertResult{Affected: affected}
+	switch {
+	case rows == 1:
+		result.Inserted = affected % 2
+		result.Updated = affected / 2
+		result.Unchanged = 1 - result.Inserted - result.Updated
+	case affected == 0:
+		result.Unchanged = int64(rows)
+	case affected == 2*int64(rows):
+		result.Updated = int64(rows)
+	default:
+		return result
+	}
+	result.Exact = true
+
+	return result
+}
+
+// CompileInserted compiles the reference to the inserted value of the column in the ON DUPLICATE KEY UPDATE clause,
+// it's the row alias on MySQL 8.0.19+, and VALUES() on the older MySQL and MariaDB.
+func (r *Grammar) CompileInserted(column string) string {
+	if r.rowAlias() {
+		return "new." + r.wrap.Column(column)
+	}
+
+	return fmt.Sprintf("values(%s)", r.wrap.Column(column))
+}
+
+// CompileUpsert compiles an INSERT ... ON DUPLICATE KEY UPDATE statement of the rows, the rows should have the same
+// columns, the values are bound rather than interpolated:
+//
+//	sql, args, err := grammar.CompileUpsert("counters", rows, mysql.Upsert{
+//		Update:      []string{"name"},
+//		Expressions: map[string]string{"hits": "`hits` + " + grammar.CompileInserted("hits")},
+//	})
+//	result, err := facades.DB().Exec(sql, args...)
+//	upserted := mysql.NewUpsertResult(len(rows), result.RowsAffected)
+func (r *Grammar) CompileUpsert(table string, rows []map[string]any, upsert Upsert) (string, []any, error) {
+	if len(rows) == 0 {
+		return "", nil, UpsertRowsRequired
+	}
+
+	columns := slices.Sorted(maps.Keys(rows[0]))
+
+	var (
+		args   []any
+		values []string
+	)
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return "", nil, UpsertColumnsMismatch.Args(i)
+		}
+		for _, column := range columns {
+			value, ok := row[column]
+			if !ok {
+				return "", nil, UpsertColumnsMismatch.Args(i)
+			}
+			args = append(args, value)
+		}
+		values = append(values, "("+strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")+")")
+	}
+
+	update := upsert.Update
+	if len(update) == 0 && len(upsert.Expressions) == 0 {
+		update = columns
+	}
+
+	var assignments []string
+	for _, column := range update {
+		assignments = append(assignments, fmt.Sprintf("%s = %s", r.wrap.Column(column), r.CompileInserted(column)))
+	}
+	for _, column := range slices.Sorted(maps.Keys(upsert.Expressions)) {
+		assignments = append(assignments, fmt.Sprintf("%s = %s", r.wrap.Column(column), upsert.Expressions[column]))
+	}
+
+	var alias string
+	if r.rowAlias() {
+		alias = " as new"
+	}
+
+	return fmt.Sprintf("insert into %s (%s) values %s%s on duplicate key update %s",
+		r.wrap.Table(table),
+		r.wrap.Columnize(columns),
+		strings.Join(values, ", "),
+		alias,
+		strings.Join(assignments, ", "),
+	), args, nil
+}
+
+// rowAlias returns whether the inserted row can be referenced via an alias, it's added by MySQL 8.0.19 and VALUES()
+// is deprecated since 8.0.20.
+func (r *Grammar) rowAlias() bool {
+	return r.versionAtLeast(semver.New(8, 0, 19, "", ""), nil)
+}
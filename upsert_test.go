@@ -0,0 +1,79 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileUpsert(t *testing.T) {
+	rows := []map[string]any{
+		{"name": "home", "hits": 1},
+		{"name": "about", "hits": 2},
+	}
+
+	tests := []struct {
+		name      string
+		grammar   *Grammar
+		upsert    Upsert
+		expectSql string
+	}{
+		{
+			name:      "row alias",
+			grammar:   NewGrammar("goravel", "goravel_", "8.0.19", Name),
+			expectSql: "insert into `goravel_pages` (`hits`, `name`) values (?, ?), (?, ?) as new on duplicate key update `hits` = new.`hits`, `name` = new.`name`",
+		},
+		{
+			name:      "values on MySQL 8.0.18",
+			grammar:   NewGrammar("goravel", "goravel_", "8.0.18", Name),
+			expectSql: "insert into `goravel_pages` (`hits`, `name`) values (?, ?), (?, ?) on duplicate key update `hits` = values(`hits`), `name` = values(`name`)",
+		},
+		{
+			name:      "values on MariaDB",
+			grammar:   NewMariadbGrammar("goravel", "goravel_", "11.4.2").Grammar,
+			expectSql: "insert into `goravel_pages` (`hits`, `name`) values (?, ?), (?, ?) on duplicate key update `hits` = values(`hits`), `name` = values(`name`)",
+		},
+		{
+			name:    "update and expressions",
+			grammar: NewGrammar("goravel", "goravel_", "8.4.3", Name),
+			upsert: Upsert{
+				Update:      []string{"name"},
+				Expressions: map[string]string{"hits": "`hits` + new.`hits`", "updated_at": "now()"},
+			},
+			expectSql: "insert into `goravel_pages` (`hits`, `name`) values (?, ?), (?, ?) as new on duplicate key update `name` = new.`name`, `hits` = `hits` + new.`hits`, `updated_at` = now()",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sql, args, err := test.grammar.CompileUpsert("pages", rows, test.upsert)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectSql, sql)
+			assert.Equal(t, []any{1, "home", 2, "about"}, args)
+		})
+	}
+
+	grammar := NewGrammar("goravel", "goravel_", "8.0.19", Name)
+	_, _, err := grammar.CompileUpsert("pages", nil, Upsert{})
+	assert.Equal(t, UpsertRowsRequired, err)
+
+	_, _, err = grammar.CompileUpsert("pages", []map[string]any{{"name": "home"}, {"title": "about"}}, Upsert{})
+	assert.Equal(t, UpsertColumnsMismatch.Args(1), err)
+}
+
+func TestCompileInserted(t *testing.T) {
+	assert.Equal(t, "new.`hits`", NewGrammar("goravel", "goravel_", "8.0.19", Name).CompileInserted("hits"))
+	assert.Equal(t, "values(`hits`)", NewGrammar("goravel", "goravel_", "5.7.44", Name).CompileInserted("hits"))
+	assert.Equal(t, "values(`hits`)", NewMariadbGrammar("goravel", "goravel_", "11.4.2").CompileInserted("hits"))
+}
+
+func TestNewUpsertResult(t *testing.T) {
+	assert.Equal(t, UpsertResult{Affected: 1, Inserted: 1, Exact: true}, NewUpsertResult(1, 1))
+	assert.Equal(t, UpsertResult{Affected: 2, Updated: 1, Exact: true}, NewUpsertResult(1, 2))
+	assert.Equal(t, UpsertResult{Unchanged: 1, Exact: true}, NewUpsertResult(1, 0))
+	assert.Equal(t, UpsertResult{Unchanged: 3, Exact: true}, NewUpsertResult(3, 0))
+	assert.Equal(t, UpsertResult{Affected: 6, Updated: 3, Exact: true}, NewUpsertResult(3, 6))
+	// 1 inserted, 1 updated and 1 unchanged row, or 3 inserted rows
+	assert.Equal(t, UpsertResult{Affected: 3}, NewUpsertResult(3, 3))
+	assert.Equal(t, UpsertResult{Affected: 5}, NewUpsertResult(3, 5))
+}